@@ -7,7 +7,13 @@ type serverConfig struct {
 
 	WorkerRPCEndpoint string // Worker
 
-	IsMainServer bool // Works on cluster mode
+	ServerID string // Identify this server in leader election
+
+	AdminToken string // Bearer token for admin endpoints
+
+	CollectSuspendThreshold uint // Suspend collecting after such many consecutive failures
+
+	MigrateRemoveDuplicatedFeeds bool // Allow removing duplicated feeds when making them unique
 
 	HeartBeatWebhooks struct { // Create a heartbeat request when ...
 		FeedCollect   string
@@ -0,0 +1,11 @@
+package consts
+
+import "time"
+
+const (
+	ACTIVITY_HALF_LIFE         = 14 * 24 * time.Hour // Weight of a post halves after this
+	ACTIVITY_MIN_OBSERVED      = 7 * 24 * time.Hour  // Shorter observations are treated as this, so few posts won't mean high rates
+	ACTIVITY_POSTS_PER_POLL    = 0.5                 // Poll when a new post is as likely as not
+	ACTIVITY_PREDICT_HORIZON   = 7 * 24 * time.Hour  // Next post predicted within this in account API
+	ACTIVITY_SUMMARY_TOP_HOURS = 3                   // Active hours listed in account API
+)
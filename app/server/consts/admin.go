@@ -0,0 +1,6 @@
+package consts
+
+const (
+	ADMIN_DEAD_LETTERS_DEFAULT_LIMIT = 20
+	ADMIN_DEAD_LETTERS_MAX_LIMIT     = 200
+)
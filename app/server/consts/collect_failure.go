@@ -0,0 +1,9 @@
+package consts
+
+import "time"
+
+const (
+	COLLECT_FAILURE_RECORDS_KEEP  = 10             // Recent failures kept for each account
+	COLLECT_FAILURE_BACKOFF_LIMIT = 24 * time.Hour // Max delay before next retry
+	COLLECT_GAP_RECORDS_KEEP      = 10             // Unresolved feed gaps kept for each account
+)
@@ -5,4 +5,13 @@ import "time"
 const (
 	JOBS_INTERVAL_FEED_COLLECT           = 10 * time.Second
 	JOBS_INTERVAL_RESUME_PAUSED_ACCOUNTS = 10 * time.Minute
+	JOBS_INTERVAL_RECONCILE_RESULTS      = 10 * time.Minute
+
+	DISPATCH_BATCH_SIZE   = 500             // Accounts claimed and published at once
+	DISPATCH_CLAIM_EXPIRE = 5 * time.Minute // Claimed accounts are due again after this if not settled
+
+	RECONCILE_RESULTS_STALE_AFTER = 30 * time.Minute // Results not processed since then are replayed
+	RECONCILE_RESULTS_BATCH_SIZE  = 1000
+	RECONCILE_RESULTS_MAX_REPLAYS = 6                  // Results still not processed after then are archived
+	RECONCILE_PROCESSED_WORKS_TTL = 7 * 24 * time.Hour // Records of processed works are kept for duplicate checks
 )
@@ -0,0 +1,12 @@
+package consts
+
+import "time"
+
+const (
+	JOB_FEED_COLLECT_DISPATCH  = "feed_collect_dispatch"
+	JOB_RESUME_PAUSED_ACCOUNTS = "resume_paused_accounts"
+	JOB_RECONCILE_RESULTS      = "reconcile_results"
+	JOBS_LOCK_KEY_PREFIX       = "cos:jobs:lock"
+	JOBS_METRICS_KEY           = "cos:jobs:metrics"
+	JOBS_LOCK_TTL              = 1 * time.Minute // Extended every 1/3 TTL when running
+)
@@ -0,0 +1,10 @@
+package consts
+
+import "time"
+
+const (
+	LEADER_LEASE_KEY      = "cos:leader:lease"
+	LEADER_TOKEN_KEY      = "cos:leader:token" // Fencing token, increases on every election
+	LEADER_LEASE_TTL      = 15 * time.Second
+	LEADER_RENEW_INTERVAL = 5 * time.Second
+)
@@ -0,0 +1,17 @@
+package admin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+func parseDeadLettersLimit(ctx *gin.Context) int {
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit <= 0 {
+		return consts.ADMIN_DEAD_LETTERS_DEFAULT_LIMIT
+	} else if limit > consts.ADMIN_DEAD_LETTERS_MAX_LIMIT {
+		return consts.ADMIN_DEAD_LETTERS_MAX_LIMIT
+	}
+	return limit
+}
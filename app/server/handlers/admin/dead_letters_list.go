@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ListDeadLetters : Peek dead-lettered works (with ?limit=), they are kept in queue
+func ListDeadLetters(ctx *gin.Context) {
+	ch, err := commonGlobal.MQ.Channel()
+	if err != nil {
+		global.Logger.Errorf("Failed to open MQ channel with error: %s", err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to connect to MQ",
+			"result":  nil,
+		})
+		return
+	}
+	defer ch.Close()
+
+	deadLetters, err := commonMQ.InspectDeadLetters(ch, parseDeadLettersLimit(ctx))
+	if err != nil {
+		global.Logger.Errorf("Failed to inspect dead letters with error: %s", err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to inspect dead letters",
+			"result":  nil,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Dead letters listed",
+		"result":  deadLetters,
+	})
+}
@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ReplayDeadLetters : Publish dead-lettered works (with ?limit=) back to their original queues
+func ReplayDeadLetters(ctx *gin.Context) {
+	ch, err := commonGlobal.MQ.Channel()
+	if err != nil {
+		global.Logger.Errorf("Failed to open MQ channel with error: %s", err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to connect to MQ",
+			"result":  nil,
+		})
+		return
+	}
+	defer ch.Close()
+
+	replayed, err := commonMQ.ReplayDeadLetters(ch, parseDeadLettersLimit(ctx))
+	if err != nil {
+		global.Logger.Errorf("Failed to replay dead letters with error: %s", err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to replay all dead letters",
+			"result":  replayed,
+		})
+		return
+	}
+
+	global.Logger.Infof("%d dead letters replayed", len(replayed))
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Dead letters replayed",
+		"result":  replayed,
+	})
+}
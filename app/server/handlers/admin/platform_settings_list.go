@@ -0,0 +1,27 @@
+package admin
+
+import (
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func ListPlatformSettings(ctx *gin.Context) {
+	platforms := make(map[string]gin.H)
+	runtimeSettings := commonPlatforms.RuntimeSettings()
+
+	for _, platformID := range commonPlatforms.IDs() {
+		meta, _ := commonPlatforms.Meta(platformID)
+		platforms[platformID] = gin.H{
+			"enabled": commonPlatforms.IsEnabled(platformID),
+			"meta":    meta,
+			"runtime": runtimeSettings[platformID],
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Platform settings listed",
+		"result":  platforms,
+	})
+}
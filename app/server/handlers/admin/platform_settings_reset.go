@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ResetPlatformSettings : Remove runtime settings of a platform, fallback to settings file and built-in values
+func ResetPlatformSettings(ctx *gin.Context) {
+	reqPlatform := ctx.Param("platform")
+
+	if !commonPlatforms.IsSupported(reqPlatform) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Platform not supported",
+			"result":  nil,
+		})
+		return
+	}
+
+	if err := commonPlatforms.DeleteRuntimeSettings(ctx, reqPlatform); err != nil {
+		global.Logger.Errorf("Failed to reset runtime settings of platform %s with error: %s", reqPlatform, err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to reset settings",
+			"result":  nil,
+		})
+		return
+	}
+
+	global.Logger.Infof("Runtime settings of platform %s reset", reqPlatform)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Platform settings reset",
+		"result":  nil,
+	})
+}
@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// SetPlatformSettings : Replace runtime settings of a platform, takes effect on all servers and workers
+func SetPlatformSettings(ctx *gin.Context) {
+	reqPlatform := ctx.Param("platform")
+
+	var settings commonTypes.PlatformSettings
+	if err := ctx.ShouldBindJSON(&settings); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to parse settings: " + err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	if err := commonPlatforms.ValidateSettings(reqPlatform, &settings); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	if err := commonPlatforms.SaveRuntimeSettings(ctx, reqPlatform, &settings); err != nil {
+		global.Logger.Errorf("Failed to save runtime settings of platform %s with error: %s", reqPlatform, err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to save settings",
+			"result":  nil,
+		})
+		return
+	}
+
+	global.Logger.Infof("Runtime settings of platform %s updated: %v", reqPlatform, settings)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Platform settings updated",
+		"result":  settings,
+	})
+}
@@ -1,38 +1,76 @@
 package public
 
 import (
+	"context"
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/joblock"
+	"github.com/Crossbell-Box/OperatorSync/app/server/leader"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"time"
 )
 
 type healthStatus struct {
-	OK      bool   `json:"ok"`
-	Message string `json:"message,omitempty"`
+	OK       bool          `json:"ok"`
+	Message  string        `json:"message,omitempty"`
+	Draining bool          `json:"draining"`
+	ServerID string        `json:"server_id"`
+	IsLeader bool          `json:"is_leader"`
+	Leader   *leader.Lease `json:"leader"`
 }
 
 func HealthCheck(ctx *gin.Context) {
-	if config.Config.IsMainServer {
-		if time.Now().Sub(config.Status.Jobs.FeedCollectLastRun) > 2*consts.JOBS_INTERVAL_FEED_COLLECT {
-			ctx.JSON(http.StatusInternalServerError, healthStatus{
-				OK:      false,
-				Message: "Feed collect work not running",
-			})
+	status := healthStatus{
+		OK:       true,
+		ServerID: config.Config.ServerID,
+		IsLeader: leader.IsLeader(),
+	}
+
+	if shutdown.Draining() {
+		// Stop routing traffic here
+		status.OK = false
+		status.Draining = true
+		status.Message = "draining"
+		ctx.JSON(http.StatusServiceUnavailable, status)
+		return
+	}
+
+	leaderCtx, cancel := context.WithTimeout(ctx, consts.LEADER_RENEW_INTERVAL)
+	defer cancel()
+	if lease, err := leader.Current(leaderCtx); err != nil {
+		global.Logger.Errorf("Failed to get current leader with error: %s", err.Error())
+	} else {
+		status.Leader = lease
+	}
+
+	if status.IsLeader {
+		// Jobs start running after elected
+		leaderSince := leader.Since()
+
+		if time.Now().Sub(latest(joblock.Get(consts.JOB_FEED_COLLECT_DISPATCH).LastAttempt, leaderSince)) > 2*consts.JOBS_INTERVAL_FEED_COLLECT {
+			status.OK = false
+			status.Message = "Feed collect work not running"
+			ctx.JSON(http.StatusInternalServerError, status)
 			return
 		}
 
-		if time.Now().Sub(config.Status.Jobs.ResumePausedAccountsLastRun) > 2*consts.JOBS_INTERVAL_RESUME_PAUSED_ACCOUNTS {
-			ctx.JSON(http.StatusInternalServerError, healthStatus{
-				OK:      false,
-				Message: "Resume paused account work not running",
-			})
+		if time.Now().Sub(latest(joblock.Get(consts.JOB_RESUME_PAUSED_ACCOUNTS).LastAttempt, leaderSince)) > 2*consts.JOBS_INTERVAL_RESUME_PAUSED_ACCOUNTS {
+			status.OK = false
+			status.Message = "Resume paused account work not running"
+			ctx.JSON(http.StatusInternalServerError, status)
 			return
 		}
 	}
 
-	ctx.JSON(http.StatusOK, healthStatus{
-		OK: true,
-	})
+	ctx.JSON(http.StatusOK, status)
+}
+
+func latest(t1 time.Time, t2 time.Time) time.Time {
+	if t1.After(t2) {
+		return t1
+	}
+	return t2
 }
@@ -9,6 +9,7 @@ func ListEndpoints(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, []string{
 		"GET    /            - List all available endpoints",
 		"GET    /healthcheck - Status and healthcheck",
+		"GET    /ready       - Readiness for traffic",
 		"GET    /platforms   - List all available platforms",
 		"GET    /metrics     - List statics metrics",
 
@@ -22,5 +23,11 @@ func ListEndpoints(ctx *gin.Context) {
 		"DELETE /v1/:character/account/unbind/:platform/:username   - Unbind platform account",
 		"GET    /v1/:character/media                                - Get media of a specified character",
 		"GET    /v1/feed/:platform/:username                        - Get feeds of a specified account",
+
+		"GET    /admin/platforms           - List platform settings (admin token required)",
+		"PUT    /admin/platforms/:platform - Update runtime settings of a platform (admin token required)",
+		"DELETE /admin/platforms/:platform - Reset runtime settings of a platform (admin token required)",
+		"GET    /admin/dead-letters        - Inspect dead-lettered works, ?limit= (admin token required)",
+		"POST   /admin/dead-letters/replay - Replay dead-lettered works, ?limit= (admin token required)",
 	})
 }
@@ -1,11 +1,11 @@
 package public
 
 import (
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
 
 func ListPlatforms(ctx *gin.Context) {
-	ctx.JSON(http.StatusOK, commonConsts.SUPPORTED_PLATFORM)
+	ctx.JSON(http.StatusOK, commonPlatforms.AllMeta())
 }
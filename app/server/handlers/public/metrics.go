@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/joblock"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
+	"github.com/Crossbell-Box/OperatorSync/app/server/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"time"
@@ -73,8 +77,14 @@ func calcMetrics() *types.Metrics {
 	m.Account.Total = 0
 	m.Account.Valid = 0
 
+	workers, err := utils.LiveWorkers(context.Background())
+	if err != nil {
+		global.Logger.Errorf("Failed to get live workers with error: %s", err.Error())
+	}
+	served := utils.ServedPlatforms(workers)
+
 	m.Platform = make(map[string]types.PlatformMetrics)
-	for platformID, _ := range commonConsts.SUPPORTED_PLATFORM {
+	for _, platformID := range commonPlatforms.IDs() {
 		var pm types.PlatformMetrics
 
 		global.DB.Scopes(models.FeedTable(models.Feed{
@@ -89,8 +99,41 @@ func calcMetrics() *types.Metrics {
 		m.Account.Total += pm.Account.Total
 		m.Account.Valid += pm.Account.Valid
 
+		pm.Workers = served[platformID]
+
 		m.Platform[platformID] = pm
 	}
 
+	m.Worker = listWorkerMetrics()
+
+	if jobs, err := joblock.List(context.Background()); err != nil {
+		global.Logger.Errorf("Failed to get job metrics with error: %s", err.Error())
+	} else {
+		m.Jobs = jobs
+	}
+
 	return &m
 }
+
+// listWorkerMetrics : Metrics reported by alive workers, stale ones are removed
+func listWorkerMetrics() map[string]commonTypes.WorkerMetrics {
+	workers := make(map[string]commonTypes.WorkerMetrics)
+
+	rawMetrics, err := commonGlobal.Redis.HGetAll(context.Background(), commonConsts.REDIS_WorkerMetricsKey).Result()
+	if err != nil {
+		global.Logger.Errorf("Failed to get worker metrics with error: %s", err.Error())
+		return workers
+	}
+
+	for workerID, raw := range rawMetrics {
+		var wm commonTypes.WorkerMetrics
+		if err := json.Unmarshal([]byte(raw), &wm); err != nil || time.Since(wm.ReportedAt) > commonConsts.REDIS_WorkerMetricsExpires {
+			// Invalid or gone
+			commonGlobal.Redis.HDel(context.Background(), commonConsts.REDIS_WorkerMetricsKey, workerID)
+			continue
+		}
+		workers[workerID] = wm
+	}
+
+	return workers
+}
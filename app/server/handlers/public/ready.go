@@ -0,0 +1,24 @@
+package public
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Ready : Readiness of this server for traffic, only unready while draining.
+// Jobs of leader are checked in HealthCheck instead, so a leader with stuck jobs still serves.
+func Ready(ctx *gin.Context) {
+	if shutdown.Draining() {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{
+			"ok":       false,
+			"draining": true,
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":       true,
+		"draining": false,
+	})
+}
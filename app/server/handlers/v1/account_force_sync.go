@@ -4,7 +4,8 @@ import (
 	"errors"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/utils"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 	"net/http"
@@ -17,13 +18,21 @@ func ForceSyncAccount(ctx *gin.Context) {
 	reqPlatform := ctx.Param("platform")
 	reqUsername := ctx.Param("username")
 
-	if _, ok := commonConsts.SUPPORTED_PLATFORM[reqPlatform]; !ok {
+	platform, ok := commonPlatforms.Meta(reqPlatform)
+	if !ok {
 		ctx.JSON(http.StatusOK, gin.H{
 			"ok":      false,
 			"message": "Platform not supported",
 			"result":  nil,
 		})
 		return
+	} else if !commonPlatforms.IsEnabled(reqPlatform) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Platform temporarily disabled",
+			"result":  nil,
+		})
+		return
 	}
 
 	global.Logger.Debugf("Account #%s (%s@%s) force sync request received.", reqCharacterID, reqUsername, reqPlatform)
@@ -53,13 +62,16 @@ func ForceSyncAccount(ctx *gin.Context) {
 		})
 	} else {
 		// Check if eligible to sync now
-		earliestNextUpdate := account.LastUpdated.Add(commonConsts.SUPPORTED_PLATFORM[reqPlatform].MinRefreshGap)
+		earliestNextUpdate := account.LastUpdated.Add(platform.MinRefreshGap)
 		if earliestNextUpdate.Before(time.Now()) {
 			account.NextUpdate = time.Now()
 		} else {
 			account.NextUpdate = earliestNextUpdate
 		}
 
+		// Lift collect suspension (if any), as user asked for it
+		utils.AccountCollectResume(&account)
+
 		// Save account
 		global.DB.Save(&account)
 
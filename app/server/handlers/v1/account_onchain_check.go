@@ -5,7 +5,7 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 	"net/http"
@@ -18,7 +18,7 @@ func CheckAccountOnChainStatus(ctx *gin.Context) {
 	reqPlatform := ctx.Param("platform")
 	reqUsername := ctx.Param("username")
 
-	if _, ok := commonConsts.SUPPORTED_PLATFORM[reqPlatform]; !ok {
+	if !commonPlatforms.IsSupported(reqPlatform) {
 		ctx.JSON(http.StatusOK, gin.H{
 			"ok":      false,
 			"message": "Platform not supported",
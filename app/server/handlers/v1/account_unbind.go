@@ -3,7 +3,7 @@ package v1
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/utils"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -15,7 +15,7 @@ func UnbindAccount(ctx *gin.Context) {
 	reqPlatform := ctx.Param("platform")
 	reqUsername := ctx.Param("username")
 
-	if _, ok := commonConsts.SUPPORTED_PLATFORM[reqPlatform]; !ok {
+	if !commonPlatforms.IsSupported(reqPlatform) {
 		ctx.JSON(http.StatusOK, gin.H{
 			"ok":      false,
 			"message": "Platform not supported",
@@ -67,7 +67,9 @@ func ListAccounts(ctx *gin.Context) {
 		accountForResponse := types.AccountWithAdditionalPropsForListResponse{
 			Account:                       rawAccount.Account,
 			OnChainStatusManageForAccount: rawAccount.OnChainStatusManageForAccount,
+			CollectStatusManageForAccount: rawAccount.CollectStatusManageForAccount,
 			IsMetadataCorrect:             utils.IsInConnectedAccounts(rawAccount.Platform, rawAccount.Username, accountsOnChain),
+			Activity:                      utils.AccountActivitySummary(&rawAccount, time.Now()),
 		}
 
 		// Parse accounts update interval to seconds
@@ -8,8 +8,8 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 	"net/http"
@@ -22,7 +22,7 @@ func ListSingleAccountFeeds(ctx *gin.Context) {
 	reqPlatform := ctx.Param("platform")
 	reqUsername := ctx.Param("username")
 
-	if _, ok := commonConsts.SUPPORTED_PLATFORM[reqPlatform]; !ok {
+	if !commonPlatforms.IsSupported(reqPlatform) {
 		ctx.JSON(http.StatusOK, gin.H{
 			"ok":      false,
 			"message": "Platform not supported",
@@ -1,19 +1,23 @@
 package inits
 
 import (
+	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func Config() error {
 	// Get from environment variables
 
 	var exist bool // If non-exist use default
+	var err error
 
 	if config.Config.DBConnString, exist = os.LookupEnv("DATABASE_CONNECTION_STRING"); !exist {
 		config.Config.DBConnString = consts.CONFIG_DEFAULT_DATABASE_CONNECTION_STRING
@@ -24,26 +28,57 @@ func Config() error {
 	if config.Config.MQConnString, exist = os.LookupEnv("MQ_CONNECTION_STRING"); !exist {
 		config.Config.MQConnString = commonConsts.CONFIG_DEFAULT_MQ_CONNECTION_STRING
 	}
+	if mqPrefetchStr, exist := os.LookupEnv("MQ_PREFETCH"); !exist {
+		config.Config.MQPrefetch = commonConsts.CONFIG_DEFAULT_MQ_PREFETCH // Default
+	} else if config.Config.MQPrefetch, err = strconv.Atoi(mqPrefetchStr); err != nil || config.Config.MQPrefetch <= 0 {
+		log.Println("Invalid MQ prefetch settings, using default value")
+		config.Config.MQPrefetch = commonConsts.CONFIG_DEFAULT_MQ_PREFETCH // Default
+	}
+	if mqMaxRedeliveryStr, exist := os.LookupEnv("MQ_MAX_REDELIVERY"); !exist {
+		config.Config.MQMaxRedelivery = commonConsts.CONFIG_DEFAULT_MQ_MAX_REDELIVERY // Default
+	} else if config.Config.MQMaxRedelivery, err = strconv.Atoi(mqMaxRedeliveryStr); err != nil || config.Config.MQMaxRedelivery < 0 {
+		log.Println("Invalid MQ max redelivery settings, using default value")
+		config.Config.MQMaxRedelivery = commonConsts.CONFIG_DEFAULT_MQ_MAX_REDELIVERY // Default
+	}
+	if shutdownTimeoutStr, exist := os.LookupEnv("SHUTDOWN_TIMEOUT"); !exist {
+		config.Config.ShutdownTimeout = commonConsts.CONFIG_DEFAULT_SHUTDOWN_TIMEOUT // Default
+	} else if config.Config.ShutdownTimeout, err = time.ParseDuration(shutdownTimeoutStr); err != nil || config.Config.ShutdownTimeout <= 0 {
+		log.Println("Invalid shutdown timeout settings, using default value")
+		config.Config.ShutdownTimeout = commonConsts.CONFIG_DEFAULT_SHUTDOWN_TIMEOUT // Default
+	}
 	if config.Config.WorkerRPCEndpoint, exist = os.LookupEnv("WORKER_RPC_ENDPOINT"); !exist {
 		config.Config.WorkerRPCEndpoint = consts.CONFIG_DEFAULT_WORKER_RPC_ENDPOINT
 	}
 	if config.Config.WorkerRPCPort, exist = os.LookupEnv("WORKER_RPC_PORT"); !exist {
 		config.Config.WorkerRPCPort = commonConsts.CONFIG_DEFAULT_WORKER_RPC_PORT
 	}
-	config.Config.IsMainServer = strings.Contains(strings.ToLower(os.Getenv("MAIN_SERVER")), "t")
-
-	if config.Config.IsMainServer {
-		// Check jobs webhook
-		if config.Config.HeartBeatWebhooks.FeedCollect, exist = os.LookupEnv("HEARTBEAT_WEBHOOK_FEED_COLLECT");
-			!exist || !utils.ValidateUri(config.Config.HeartBeatWebhooks.FeedCollect) {
-			config.Config.HeartBeatWebhooks.FeedCollect = "" // Nope
-		}
-		if config.Config.HeartBeatWebhooks.AccountResume, exist = os.LookupEnv("HEARTBEAT_WEBHOOK_ACCOUNT_RESUME");
-			!exist || !utils.ValidateUri(config.Config.HeartBeatWebhooks.AccountResume) {
-			config.Config.HeartBeatWebhooks.AccountResume = "" // Nope
+	config.Config.PlatformSettingsFile = os.Getenv("PLATFORM_SETTINGS_FILE") // Optional
+	if collectSuspendThresholdStr, exist := os.LookupEnv("COLLECT_SUSPEND_THRESHOLD"); !exist {
+		config.Config.CollectSuspendThreshold = consts.CONFIG_DEFAULT_COLLECT_SUSPEND_THRESHOLD // Default
+	} else if threshold, err := strconv.ParseUint(collectSuspendThresholdStr, 10, 32); err != nil {
+		log.Println("Invalid collect suspend threshold settings, using default value")
+		config.Config.CollectSuspendThreshold = consts.CONFIG_DEFAULT_COLLECT_SUSPEND_THRESHOLD // Default
+	} else {
+		config.Config.CollectSuspendThreshold = uint(threshold) // 0 means never suspend
+	}
+	config.Config.AdminToken = os.Getenv("ADMIN_TOKEN") // Admin endpoints disabled if empty
+	config.Config.MigrateRemoveDuplicatedFeeds = os.Getenv("MIGRATE_REMOVE_DUPLICATED_FEEDS") == "true" // Explicitly, for once
+	if config.Config.ServerID, exist = os.LookupEnv("SERVER_ID"); !exist {
+		if config.Config.ServerID, err = os.Hostname(); err != nil {
+			return fmt.Errorf("please specify server ID, failed to get hostname: %v", err)
 		}
 	}
 
+	// Check jobs webhook (only sent by leader)
+	if config.Config.HeartBeatWebhooks.FeedCollect, exist = os.LookupEnv("HEARTBEAT_WEBHOOK_FEED_COLLECT");
+		!exist || !utils.ValidateUri(config.Config.HeartBeatWebhooks.FeedCollect) {
+		config.Config.HeartBeatWebhooks.FeedCollect = "" // Nope
+	}
+	if config.Config.HeartBeatWebhooks.AccountResume, exist = os.LookupEnv("HEARTBEAT_WEBHOOK_ACCOUNT_RESUME");
+		!exist || !utils.ValidateUri(config.Config.HeartBeatWebhooks.AccountResume) {
+		config.Config.HeartBeatWebhooks.AccountResume = "" // Nope
+	}
+
 	config.Config.DevelopmentMode = !strings.Contains(strings.ToLower(os.Getenv("MODE")), "prod")
 
 	if config.Config.DevelopmentMode {
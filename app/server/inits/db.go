@@ -1,12 +1,14 @@
 package inits
 
 import (
+	"context"
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -35,6 +37,13 @@ func DB() error {
 		return fmt.Errorf("failed to run migrations: %v", err)
 	}
 
+	// Closed after transactions in progress finished
+	shutdown.OnClose(func(_ context.Context) {
+		if sqlDB, err := global.DB.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
 	return nil
 }
 
@@ -43,12 +52,13 @@ func mig() error {
 		&models.Account{},
 		&models.Media{},
 		&models.Character{},
+		&models.ProcessedWork{},
 	)
 	if err != nil {
 		return err
 	}
 
-	for platformID, _ := range commonConsts.SUPPORTED_PLATFORM {
+	for _, platformID := range commonPlatforms.IDs() {
 		feedWithPlatform := models.Feed{
 			Feed: types.Feed{
 				Platform: platformID, // Cannot be initialized with platform specified
@@ -60,6 +70,77 @@ func mig() error {
 			AutoMigrate(&feedWithPlatform); err != nil {
 			return err
 		}
+
+		if err = migFeedUniqueKey(feedWithPlatform); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// migFeedUniqueKey : Fill dedup keys of existing feeds, then make them unique. Only once, skipped if index exists.
+// Duplicated feeds are never removed unless MIGRATE_REMOVE_DUPLICATED_FEEDS is set, refuse to start instead.
+func migFeedUniqueKey(feedWithPlatform models.Feed) error {
+	indexName := models.FeedUniqueKeyIndex(feedWithPlatform)
+	feedTable := models.FeedTableName(feedWithPlatform)
+	if global.DB.Migrator().HasIndex(feedTable, indexName) {
+		return nil
+	}
+
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf(
+			"UPDATE %s SET dedup_key = %s WHERE dedup_key = ''",
+			feedTable, models.FeedDedupKeySQL,
+		)).Error; err != nil {
+			return err
+		}
+
+		// Keep the one on chain, then the earliest
+		duplicatedCondition := fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM %[1]s b
+			WHERE b.account_id = a.account_id AND b.dedup_key = a.dedup_key AND b.id <> a.id AND (
+				(COALESCE(a.transaction, '') = '' AND COALESCE(b.transaction, '') <> '') OR
+				((COALESCE(a.transaction, '') = '') = (COALESCE(b.transaction, '') = '') AND a.id > b.id)
+			)
+		)`, feedTable)
+
+		var duplicated int64
+		if err := tx.Table(feedTable + " a").Where(duplicatedCondition).Count(&duplicated).Error; err != nil {
+			return err
+		}
+		if duplicated > 0 {
+			if !config.Config.MigrateRemoveDuplicatedFeeds {
+				return fmt.Errorf(
+					"%d duplicated feeds found in %s, review them and start once with MIGRATE_REMOVE_DUPLICATED_FEEDS=true to remove them",
+					duplicated, feedTable,
+				)
+			}
+
+			var removed []struct {
+				ID        uint
+				AccountID uint
+				DedupKey  string
+			}
+			if err := tx.Raw(fmt.Sprintf(
+				"DELETE FROM %s a WHERE %s RETURNING a.id, a.account_id, a.dedup_key",
+				feedTable, duplicatedCondition,
+			)).Scan(&removed).Error; err != nil {
+				return err
+			}
+			for _, feed := range removed {
+				global.Logger.Warnf("Duplicated feed #%d of account #%d (%s) removed from %s", feed.ID, feed.AccountID, feed.DedupKey, feedTable)
+			}
+			global.Logger.Warnf("%d duplicated feeds removed from %s", len(removed), feedTable)
+		}
+
+		// Replaced, only unique by GUID
+		if err := tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS idx_%s_account_guid", feedTable)).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(fmt.Sprintf(
+			"CREATE UNIQUE INDEX %s ON %s (account_id, dedup_key)",
+			indexName, feedTable,
+		)).Error
+	})
+}
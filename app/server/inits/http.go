@@ -0,0 +1,40 @@
+package inits
+
+import (
+	"context"
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"os"
+)
+
+// HTTP : Serve in background, kept serving while draining so health check can report it
+func HTTP(engine *gin.Engine) {
+	srv := &http.Server{
+		Addr:    httpAddress(),
+		Handler: engine,
+	}
+
+	go func() {
+		global.Logger.Infof("Listening and serving HTTP on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			global.Logger.Fatal("Failed to start service: ", err.Error())
+		}
+	}()
+
+	shutdown.OnClose(func(ctx context.Context) {
+		if err := srv.Shutdown(ctx); err != nil {
+			global.Logger.Errorf("Failed to shutdown HTTP server with error: %s", err.Error())
+		}
+	})
+}
+
+// httpAddress : Same as gin's default, on PORT or 8080
+func httpAddress() string {
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
+	}
+	return ":8080"
+}
@@ -2,8 +2,9 @@ package inits
 
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
+	"github.com/Crossbell-Box/OperatorSync/app/server/joblock"
 	"github.com/Crossbell-Box/OperatorSync/app/server/jobs"
-	"time"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
 )
 
 func Jobs() error {
@@ -13,13 +14,13 @@ func Jobs() error {
 		return err
 	}
 
-	if config.Config.IsMainServer {
-		// Start dispatch flush works
-		config.Status.Jobs.FeedCollectLastRun = time.Now()
-		jobs.FeedCollectStartDispatchWork()
-		config.Status.Jobs.ResumePausedAccountsLastRun = time.Now()
-		jobs.ResumePausedAccounts()
-	}
+	// Lock jobs across replicas
+	joblock.Init(commonGlobal.Redis, config.Config.ServerID)
+
+	// Start dispatch flush works, they only run on leader
+	jobs.FeedCollectStartDispatchWork()
+	jobs.ResumePausedAccounts()
+	jobs.FeedCollectStartReconcileResults()
 
 	return nil
 
@@ -0,0 +1,26 @@
+package inits
+
+import (
+	"context"
+	"github.com/Crossbell-Box/OperatorSync/app/server/config"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/leader"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+)
+
+func Leader() error {
+	// Every server campaigns, only the leader runs scheduler jobs
+	leader.Start(config.Config.ServerID)
+
+	// Let another server take over while draining, running jobs still hold their locks
+	shutdown.OnStop(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), consts.LEADER_RENEW_INTERVAL)
+		defer cancel()
+		if err := leader.Resign(ctx); err != nil {
+			global.Logger.Errorf("Failed to resign leader with error: %s", err.Error())
+		}
+	})
+
+	return nil
+}
@@ -22,6 +22,7 @@ func ginInit(middleware ...gin.HandlerFunc) *gin.Engine {
 	}
 
 	engine := gin.Default()
+	engine.UseRawPath = true // Keep escaped slashes in params (like feed URL as username)
 
 	for _, mid := range middleware {
 		engine.Use(mid)
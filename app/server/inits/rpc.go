@@ -1,14 +1,20 @@
 package inits
 
 import (
+	"context"
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 )
 
 func RPC() error {
 	global.RPC = types.NewPool(fmt.Sprintf("%s:%s", config.Config.WorkerRPCEndpoint, config.Config.WorkerRPCPort), false)
 
+	shutdown.OnClose(func(_ context.Context) {
+		_ = global.RPC.Close()
+	})
+
 	return nil
 }
@@ -0,0 +1,154 @@
+package joblock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
+)
+
+// ErrLocked : Job is running elsewhere
+var ErrLocked = errors.New("job locked")
+
+// ErrLockLost : Lock expired or taken by others
+var ErrLockLost = errors.New("job lock lost")
+
+type Lock interface {
+	Extend(ctx context.Context, ttl time.Duration) error
+	Unlock(ctx context.Context) error
+}
+
+type Locker interface {
+	// TryLock : Returns ErrLocked if held by others
+	TryLock(ctx context.Context, job string, ttl time.Duration) (Lock, error)
+}
+
+// LocalLocker : Works within this process only
+type LocalLocker struct {
+	mu    sync.Mutex
+	locks map[string]*localLock
+}
+
+type localLock struct {
+	locker    *LocalLocker
+	job       string
+	expiresAt time.Time
+}
+
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{
+		locks: make(map[string]*localLock),
+	}
+}
+
+func (l *LocalLocker) TryLock(_ context.Context, job string, ttl time.Duration) (Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if held, ok := l.locks[job]; ok && time.Now().Before(held.expiresAt) {
+		return nil, ErrLocked
+	}
+	lock := &localLock{
+		locker:    l,
+		job:       job,
+		expiresAt: time.Now().Add(ttl),
+	}
+	l.locks[job] = lock
+	return lock, nil
+}
+
+func (ll *localLock) Extend(_ context.Context, ttl time.Duration) error {
+	ll.locker.mu.Lock()
+	defer ll.locker.mu.Unlock()
+
+	if ll.locker.locks[ll.job] != ll || !time.Now().Before(ll.expiresAt) {
+		return ErrLockLost
+	}
+	ll.expiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+func (ll *localLock) Unlock(_ context.Context) error {
+	ll.locker.mu.Lock()
+	defer ll.locker.mu.Unlock()
+
+	if ll.locker.locks[ll.job] != ll {
+		return ErrLockLost
+	}
+	delete(ll.locker.locks, ll.job)
+	return nil
+}
+
+// RedisLocker : Works across processes and replicas
+type RedisLocker struct {
+	client *redis.Client
+}
+
+type redisLock struct {
+	client *redis.Client
+	key    string
+	value  string // Identify lock owner
+}
+
+// extendScript : Extend the lock only if still owned
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// unlockScript : Release the lock only if still owned
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func NewRedisLocker(client *redis.Client) *RedisLocker {
+	return &RedisLocker{
+		client: client,
+	}
+}
+
+func (l *RedisLocker) TryLock(ctx context.Context, job string, ttl time.Duration) (Lock, error) {
+	owner := make([]byte, 16)
+	if _, err := rand.Read(owner); err != nil {
+		return nil, err
+	}
+	lock := &redisLock{
+		client: l.client,
+		key:    fmt.Sprintf("%s:%s", consts.JOBS_LOCK_KEY_PREFIX, job),
+		value:  hex.EncodeToString(owner),
+	}
+	if ok, err := l.client.SetNX(ctx, lock.key, lock.value, ttl).Result(); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrLocked
+	}
+	return lock, nil
+}
+
+func (rl *redisLock) Extend(ctx context.Context, ttl time.Duration) error {
+	if ok, err := extendScript.Run(ctx, rl.client, []string{rl.key}, rl.value, ttl.Milliseconds()).Bool(); err != nil {
+		return err
+	} else if !ok {
+		return ErrLockLost
+	}
+	return nil
+}
+
+func (rl *redisLock) Unlock(ctx context.Context) error {
+	if ok, err := unlockScript.Run(ctx, rl.client, []string{rl.key}, rl.value).Bool(); err != nil {
+		return err
+	} else if !ok {
+		return ErrLockLost
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package joblock
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/types"
+	"github.com/redis/go-redis/v9"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+)
+
+var (
+	locker  Locker        = NewLocalLocker()
+	client  *redis.Client // Share metrics across replicas if set
+	nodeID  string
+	metrics = make(map[string]types.JobMetrics)
+	mu      sync.Mutex
+)
+
+// Init : Lock with redis across replicas, or within this process if client is nil
+func Init(redisClient *redis.Client, id string) {
+	mu.Lock()
+	metrics = make(map[string]types.JobMetrics)
+	mu.Unlock()
+
+	nodeID = id
+	client = redisClient
+	if redisClient != nil {
+		locker = NewRedisLocker(redisClient)
+	} else {
+		locker = NewLocalLocker()
+	}
+}
+
+// Run : Run job exclusively, lock is extended until fn returns.
+// Returns ErrLocked if running elsewhere, or ErrLockLost if lock lost halfway (fn's ctx would be canceled).
+func Run(job string, ttl time.Duration, fn func(ctx context.Context) error) error {
+	record(job, func(m *types.JobMetrics) {
+		m.LastAttempt = time.Now()
+	})
+
+	lock, err := locker.TryLock(context.Background(), job, ttl)
+	if err != nil {
+		record(job, func(m *types.JobMetrics) {
+			m.Skips++
+		})
+		return err
+	}
+
+	startAt := time.Now()
+	record(job, func(m *types.JobMetrics) {
+		m.LastStart = startAt
+		m.Running = true
+		m.RunBy = nodeID
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var isLost atomic.Bool
+	extendDone := make(chan struct{})
+	go func() {
+		defer close(extendDone)
+		t := time.NewTicker(ttl / 3)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := lock.Extend(ctx, ttl); errors.Is(err, ErrLockLost) {
+					global.Logger.Errorf("Lock of job %s lost, canceling", job)
+					isLost.Store(true)
+					cancel()
+					return
+				} else if err != nil && ctx.Err() == nil {
+					global.Logger.Errorf("Failed to extend lock of job %s with error: %s", job, err.Error())
+				}
+			}
+		}
+	}()
+
+	err = fn(ctx)
+	cancel()
+	<-extendDone
+
+	if isLost.Load() {
+		if err == nil {
+			err = ErrLockLost
+		}
+	} else if unlockErr := lock.Unlock(context.Background()); unlockErr != nil {
+		global.Logger.Errorf("Failed to unlock job %s with error: %s", job, unlockErr.Error())
+	}
+
+	finishAt := time.Now()
+	record(job, func(m *types.JobMetrics) {
+		m.LastFinish = finishAt
+		m.LastDuration = finishAt.Sub(startAt)
+		m.Running = false
+		m.Runs++
+		if err != nil {
+			m.Failures++
+			m.LastOutcome = OutcomeFailed
+			m.LastError = err.Error()
+		} else {
+			m.LastOutcome = OutcomeSucceeded
+			m.LastError = ""
+		}
+	})
+
+	return err
+}
+
+// Get : Metrics of job run by this server
+func Get(job string) types.JobMetrics {
+	mu.Lock()
+	defer mu.Unlock()
+	return metrics[job]
+}
+
+// List : Metrics of all jobs, latest run from any server
+func List(ctx context.Context) (map[string]types.JobMetrics, error) {
+	result := make(map[string]types.JobMetrics)
+	if client == nil {
+		mu.Lock()
+		defer mu.Unlock()
+		for job, m := range metrics {
+			result[job] = m
+		}
+		return result, nil
+	}
+
+	rawMetrics, err := client.HGetAll(ctx, consts.JOBS_METRICS_KEY).Result()
+	if err != nil {
+		return nil, err
+	}
+	for job, raw := range rawMetrics {
+		var m types.JobMetrics
+		if err := json.Unmarshal([]byte(raw), &m); err != nil {
+			continue
+		}
+		result[job] = m
+	}
+	return result, nil
+}
+
+func record(job string, update func(m *types.JobMetrics)) {
+	mu.Lock()
+	m := metrics[job]
+	update(&m)
+	metrics[job] = m
+	mu.Unlock()
+
+	if client != nil && m.RunBy == nodeID {
+		// Only the server running it reports
+		if mBytes, err := json.Marshal(&m); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), consts.JOBS_LOCK_TTL/3)
+			defer cancel()
+			if err := client.HSet(ctx, consts.JOBS_METRICS_KEY, job, mBytes).Err(); err != nil {
+				global.Logger.Errorf("Failed to report metrics of job %s with error: %s", job, err.Error())
+			}
+		}
+	}
+}
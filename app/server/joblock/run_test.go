@@ -0,0 +1,67 @@
+package joblock
+
+import (
+	"context"
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	global.Logger = zap.NewNop().Sugar()
+	Init(nil, "test")
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = Run("test_run", time.Minute, func(ctx context.Context) error {
+			close(started)
+			<-finish
+			return nil
+		})
+	}()
+	<-started
+
+	if err := Run("test_run", time.Minute, func(ctx context.Context) error {
+		return nil
+	}); !errors.Is(err, ErrLocked) {
+		t.Errorf("expected locked, got %v", err)
+	}
+	if m := Get("test_run"); !m.Running || m.Skips != 1 {
+		t.Errorf("unexpected metrics while running: %+v", m)
+	}
+
+	close(finish)
+	<-done
+
+	failure := errors.New("failure")
+	if err := Run("test_run", time.Minute, func(ctx context.Context) error {
+		return failure
+	}); !errors.Is(err, failure) {
+		t.Errorf("expected failure, got %v", err)
+	}
+	if m := Get("test_run"); m.Running || m.Runs != 2 || m.Failures != 1 || m.LastOutcome != OutcomeFailed {
+		t.Errorf("unexpected metrics after runs: %+v", m)
+	}
+}
+
+func TestRunExtend(t *testing.T) {
+	global.Logger = zap.NewNop().Sugar()
+	Init(nil, "test")
+
+	// Runs longer than TTL, should be extended
+	if err := Run("test_extend", 30*time.Millisecond, func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil
+		}
+	}); err != nil {
+		t.Errorf("lock should be extended, got %v", err)
+	}
+}
@@ -3,15 +3,24 @@ package jobs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/joblock"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
+	"github.com/Crossbell-Box/OperatorSync/app/server/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"net/http"
+	"sort"
 	"time"
 )
 
@@ -31,7 +40,10 @@ func FeedCollectStartDispatchWork() {
 				}
 			}
 			notifyClose := make(chan *amqp.Error)
-			ch, q := prepareFeedCollectQueue(commonConsts.MQSETTINGS_FeedCollectDispatchQueueName, notifyClose)
+			ch := prepareFeedCollectQueue(notifyClose)
+			if err := ch.Confirm(false); err != nil {
+				global.Logger.Fatalf("Failed to enable publisher confirms with error: %s", err.Error())
+			}
 			isPendingRestart := false
 			for {
 				if isPendingRestart {
@@ -39,7 +51,12 @@ func FeedCollectStartDispatchWork() {
 				}
 				select {
 				case <-t.C:
-					go dispatchAllFeedCollectWorks(ch, q.Name)
+					startJob("FeedCollectDispatch", func() {
+						dispatchAllFeedCollectWorks(ch)
+					})
+				case <-shutdown.Stopping():
+					// No more runs, channel is kept open for runs in progress
+					return
 				case err := <-notifyClose:
 					if err != nil {
 						global.Logger.Errorf("MQ channel closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
@@ -51,15 +68,7 @@ func FeedCollectStartDispatchWork() {
 	}()
 }
 
-var (
-	_isFeedCollectDispatchWorkProcessing bool
-)
-
-func init() {
-	_isFeedCollectDispatchWorkProcessing = false
-}
-
-func dispatchAllFeedCollectWorks(ch *amqp.Channel, queueName string) {
+func dispatchAllFeedCollectWorks(ch *amqp.Channel) {
 
 	if config.Config.HeartBeatWebhooks.FeedCollect != "" {
 		// Send heartbeat packet
@@ -70,51 +79,138 @@ func dispatchAllFeedCollectWorks(ch *amqp.Channel, queueName string) {
 		}
 	}
 
+	if err := joblock.Run(consts.JOB_FEED_COLLECT_DISPATCH, consts.JOBS_LOCK_TTL, func(ctx context.Context) error {
+		return dispatchFeedCollectWorks(ctx, ch)
+	}); errors.Is(err, joblock.ErrLocked) {
+		global.Logger.Warn("Another FeedCollectDispatch work is running, skip this.")
+	} else if err != nil {
+		global.Logger.Errorf("FeedCollectDispatch work failed with error: %s", err.Error())
+	}
+}
+
+func dispatchFeedCollectWorks(ctx context.Context, ch *amqp.Channel) error {
+
 	nowTime := time.Now()
 
-	config.Status.Jobs.FeedCollectLastRun = nowTime
+	global.Logger.Debugf("Start dispatching feeds collect works at %v ...", nowTime)
 
-	if _isFeedCollectDispatchWorkProcessing {
-		global.Logger.Warn("Another FeedCollectDispatch work is running, skip this.")
-		return
+	// Unsupported platforms
+	// Might be caused by old feed collect work returns
+	// Disable accounts
+	if err := global.DB.Where("platform NOT IN ?", commonPlatforms.IDs()).Delete(&models.Account{}).Error; err != nil {
+		global.Logger.Errorf("Failed to disable accounts of unsupported platforms with error: %s", err.Error())
 	}
 
-	// Set busy flag
-	global.Logger.Debugf("Lock busy flag for feed collect work.")
-	_isFeedCollectDispatchWorkProcessing = true
-	defer func() {
-		global.Logger.Debugf("Unlock busy flag for feed collect work.")
-		_isFeedCollectDispatchWorkProcessing = false
-	}()
+	// Disabled by settings, keep accounts and wait for platform enabled
+	var enabledPlatforms []string
+	for _, platformID := range commonPlatforms.IDs() {
+		if commonPlatforms.IsEnabled(platformID) {
+			enabledPlatforms = append(enabledPlatforms, platformID)
+		}
+	}
+	if len(enabledPlatforms) == 0 {
+		global.Logger.Debug("No platforms enabled currently")
+		return nil
+	}
 
-	global.Logger.Debugf("Start dispatching feeds collect works at %v ...", nowTime)
+	// No worker to collect, keep accounts due and wait for workers
+	if workers, err := utils.LiveWorkers(ctx); err != nil {
+		// Works would wait in queues anyway
+		global.Logger.Errorf("Failed to get live workers with error: %s", err.Error())
+	} else {
+		served := utils.ServedPlatforms(workers)
+		var servedPlatforms []string
+		for _, platformID := range enabledPlatforms {
+			if served[platformID] > 0 {
+				servedPlatforms = append(servedPlatforms, platformID)
+			} else {
+				global.Logger.Warnf("No live worker serves platform %s, skip dispatching", platformID)
+			}
+		}
+		enabledPlatforms = servedPlatforms
+	}
+	if len(enabledPlatforms) == 0 {
+		global.Logger.Debug("No platforms served currently")
+		return nil
+	}
 
-	// Accounts need update
-	var accountsNeedUpdate []models.Account
+	var (
+		lastID          uint
+		dispatchedCount int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			// Lock lost, let the new holder continue
+			return err
+		}
 
-	global.DB.Find(&accountsNeedUpdate, "next_update < ?", nowTime)
+		accounts, err := claimDueAccounts(nowTime, enabledPlatforms, lastID)
+		if err != nil {
+			return err
+		} else if len(accounts) == 0 {
+			break
+		}
+		lastID = accounts[len(accounts)-1].ID
 
-	if len(accountsNeedUpdate) == 0 {
+		confirmed, err := dispatchFeedCollectBatch(ch, nowTime, accounts)
+		dispatchedCount += confirmed
+		if err != nil {
+			return err
+		}
+	}
+
+	if dispatchedCount == 0 {
 		global.Logger.Debug("No accounts need update currently")
-		return
+	} else {
+		global.Logger.Debugf("Feeds collect works dispatched for %d accounts.", dispatchedCount)
 	}
 
-	global.Logger.Debugf("Found %d accounts need update", len(accountsNeedUpdate))
+	return nil
+}
+
+// claimDueAccounts : Next page (by ID) of due accounts, claimed by pushing NextUpdate away,
+// so they won't be picked by others (or again if we crashed halfway) until claim expires
+func claimDueAccounts(nowTime time.Time, platforms []string, afterID uint) ([]models.Account, error) {
+	var accounts []models.Account
+
+	dueAccounts := global.DB.
+		Model(&models.Account{}).
+		Select("id").
+		Where("next_update < ? AND is_collect_suspended = ?", nowTime, false).
+		Where("platform IN ? AND id > ?", platforms, afterID).
+		Order("id").
+		Limit(consts.DISPATCH_BATCH_SIZE).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"})
+
+	if err := global.DB.
+		Model(&accounts).
+		Clauses(clause.Returning{}).
+		Where("id IN (?)", dueAccounts).
+		Update("next_update", nowTime.Add(consts.DISPATCH_CLAIM_EXPIRE)).Error; err != nil {
+		global.Logger.Errorf("Failed to claim due accounts with error: %s", err.Error())
+		return nil, err
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].ID < accounts[j].ID
+	})
+
+	return accounts, nil
+}
+
+// dispatchFeedCollectBatch : Publish works of claimed accounts, then advance accounts confirmed by broker,
+// and release the others to be dispatched next time
+func dispatchFeedCollectBatch(ch *amqp.Channel, nowTime time.Time, accounts []models.Account) (int, error) {
+	confirmations := make([]*amqp.DeferredConfirmation, len(accounts))
 
 	// Dispatch update works
-	for _, account := range accountsNeedUpdate {
+	for index := range accounts {
+		account := &accounts[index]
 
 		global.Logger.Debugf("Dispatching account update work for #%d (%s@%s)", account.ID, account.Username, account.Platform)
 
 		// Update account settings
-		platform, ok := commonConsts.SUPPORTED_PLATFORM[account.Platform]
-		if !ok {
-			// Unsupported platform
-			// Might be caused by old feed collect work returns
-			// Disable account
-			global.DB.Delete(&account)
-			continue
-		}
+		platform, _ := commonPlatforms.Meta(account.Platform)
 		interv := nowTime.Sub(account.LastUpdated)
 		if interv < platform.MinRefreshGap {
 			interv = platform.MinRefreshGap
@@ -134,31 +230,70 @@ func dispatchAllFeedCollectWorks(ch *amqp.Channel, queueName string) {
 			DropAfter:  account.NextUpdate, // If cannot be performed before DDL, work fails (cause new work would replace current one)
 		}
 
-		if err := DispatchSingleFeedCollectWork(ch, &work, queueName); err != nil {
+		if confirmation, err := publishFeedCollectWork(ch, &work); err != nil {
 			global.Logger.Errorf("Failed to dispatch work: %v", work)
 		} else {
-			// Update account
-			global.DB.Save(&account)
+			confirmations[index] = confirmation
+		}
+	}
+
+	// Wait for broker
+	waitCtx, cancel := context.WithTimeout(context.Background(), commonConsts.MQSETTINGS_PublishTimeOut)
+	defer cancel()
 
-			global.Logger.Debugf("Account update work %v for #%d (%s@%s) dispatched successfully", work, account.ID, account.Username, account.Platform)
+	var (
+		confirmedAccounts []models.Account
+		releasedIDs       []uint
+	)
+	for index, confirmation := range confirmations {
+		account := accounts[index]
+		if confirmation == nil {
+			releasedIDs = append(releasedIDs, account.ID)
+		} else if acked, err := confirmation.WaitContext(waitCtx); err != nil || !acked {
+			global.Logger.Errorf("Account update work for #%d (%s@%s) not confirmed by broker", account.ID, account.Username, account.Platform)
+			releasedIDs = append(releasedIDs, account.ID)
+		} else {
+			confirmedAccounts = append(confirmedAccounts, account)
 		}
+	}
 
+	if err := global.DB.Transaction(func(tx *gorm.DB) error {
+		for _, account := range confirmedAccounts {
+			if err := tx.Model(&account).Updates(map[string]interface{}{
+				"update_interval": account.UpdateInterval,
+				"next_update":     account.NextUpdate,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if len(releasedIDs) > 0 {
+			if err := tx.Model(&models.Account{}).Where("id IN ?", releasedIDs).Update("next_update", nowTime).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		// Claims expire later, so accounts would not be lost
+		global.Logger.Errorf("Failed to update dispatched accounts with error: %s", err.Error())
+		return 0, err
 	}
 
-	global.Logger.Debugf("Feeds collect works dispatched for %d accounts.", len(accountsNeedUpdate))
+	global.Logger.Debugf("Account update works dispatched for %d accounts, %d released", len(confirmedAccounts), len(releasedIDs))
+
+	return len(confirmedAccounts), nil
 }
 
-func DispatchSingleFeedCollectWork(ch *amqp.Channel, work *commonTypes.WorkDispatched, queueName string) error {
+func publishFeedCollectWork(ch *amqp.Channel, work *commonTypes.WorkDispatched) (*amqp.DeferredConfirmation, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), commonConsts.MQSETTINGS_PublishTimeOut)
 	defer cancel()
 
 	if workBytes, err := json.Marshal(&work); err != nil {
 		global.Logger.Error("Failed to marshall work: ", work)
-		return err
-	} else if err = ch.PublishWithContext(
+		return nil, err
+	} else if confirmation, err := ch.PublishWithDeferredConfirmWithContext(
 		ctx,
-		"",
-		queueName,
+		commonConsts.MQSETTINGS_FeedCollectDispatchExchangeName,
+		commonMQ.FeedCollectDispatchRoutingKey(work.Platform), // Routed to queue of its platform
 		false,
 		false,
 		amqp.Publishing{
@@ -168,7 +303,8 @@ func DispatchSingleFeedCollectWork(ch *amqp.Channel, work *commonTypes.WorkDispa
 		},
 	); err != nil {
 		global.Logger.Errorf("Failed to dispatch work %v with error %s", work, err.Error())
-		return err
+		return nil, err
+	} else {
+		return confirmation, nil
 	}
-	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
@@ -12,13 +13,24 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	commonResults "github.com/Crossbell-Box/OperatorSync/common/results"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"sort"
 	"time"
 )
 
+// errUnrecoverable : Retry won't help, send to dead letter queue directly
+var errUnrecoverable = errors.New("unrecoverable")
+
+// errWorkProcessed : Duplicated delivery, nothing to do
+var errWorkProcessed = errors.New("work processed")
+
 func FeedCollectStartRetrieveWork() error {
 
 	global.Logger.Debug("Feed Collect start listen on retrieve works...")
@@ -33,11 +45,14 @@ func FeedCollectStartRetrieveWork() error {
 				}
 			}
 			notifyClose := make(chan *amqp.Error)
-			ch, q := prepareFeedCollectQueue(commonConsts.MQSETTINGS_FeedCollectRetrieveQueueName, notifyClose)
+			ch := prepareFeedCollectQueue(notifyClose)
+			if err := ch.Qos(config.Config.MQPrefetch, 0, false); err != nil {
+				global.Logger.Fatalf("Failed to set MQ prefetch with error: %s", err.Error())
+			}
 			deliveries, err := ch.Consume(
-				q.Name,
-				"",
-				true,
+				commonConsts.MQSETTINGS_FeedCollectRetrieveQueueName,
+				config.Config.ServerID, // Consumer tag, to cancel on shutdown
+				false,
 				false,
 				false,
 				false,
@@ -53,14 +68,18 @@ func FeedCollectStartRetrieveWork() error {
 				}
 				select {
 				case d := <-deliveries:
-					switch d.Headers[commonConsts.MQSETTINGS_FeedCollectIdentifierField] {
-					case commonConsts.MQSETTINGS_FeedCollectSucceededIdentifier:
-						feedCollectHandleSucceeded(&d)
-					case commonConsts.MQSETTINGS_FeedCollectFailedIdentifier:
-						feedCollectHandleFailed(&d)
-					default:
-						global.Logger.Errorf("Undefined message received: %v", d)
+					if !shutdown.TryTrack() {
+						// Draining, left unacknowledged to be requeued
+						continue
+					}
+					feedCollectHandleRetrieved(ch, &d)
+					shutdown.Done()
+				case <-shutdown.Stopping():
+					// Stop receiving new results, channel is kept open for results in progress to settle
+					if err := ch.Cancel(config.Config.ServerID, false); err != nil {
+						global.Logger.Errorf("Failed to stop consuming work results with error: %s", err.Error())
 					}
+					return
 				case err := <-notifyClose:
 					if err != nil {
 						global.Logger.Errorf("MQ channel closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
@@ -75,20 +94,60 @@ func FeedCollectStartRetrieveWork() error {
 	return nil
 }
 
-func feedCollectHandleSucceeded(d *amqp.Delivery) {
+// feedCollectHandleRetrieved : Process work result, then settle it (retry later if failed to process)
+func feedCollectHandleRetrieved(ch *amqp.Channel, d *amqp.Delivery) {
+	if ok, err := commonMQ.CheckRedelivered(ch, d, config.Config.MQMaxRedelivery); err != nil {
+		global.Logger.Errorf("Failed to handle redelivered work result with error: %s", err.Error())
+		return
+	} else if !ok {
+		global.Logger.Warn("Work result redelivered, might be interrupted last time: ", string(d.Body))
+		return
+	}
+
+	var err error
+	switch d.Headers[commonConsts.MQSETTINGS_FeedCollectIdentifierField] {
+	case commonConsts.MQSETTINGS_FeedCollectSucceededIdentifier:
+		err = feedCollectHandleSucceeded(d)
+	case commonConsts.MQSETTINGS_FeedCollectFailedIdentifier:
+		err = feedCollectHandleFailed(d)
+	case commonConsts.MQSETTINGS_FeedCollectExpiredIdentifier:
+		err = feedCollectHandleExpired(d)
+	default:
+		global.Logger.Errorf("Undefined message received: %v", d)
+		_ = d.Nack(false, false) // To dead letter queue
+		return
+	}
+
+	if errors.Is(err, errUnrecoverable) {
+		_ = d.Nack(false, false) // To dead letter queue
+	} else if err != nil {
+		if err := commonMQ.Retry(ch, d, config.Config.MQMaxRedelivery); err != nil {
+			global.Logger.Errorf("Failed to retry work result with error: %s", err.Error())
+		}
+	} else {
+		_ = d.Ack(false)
+	}
+}
+
+func feedCollectHandleSucceeded(d *amqp.Delivery) error {
 	global.Logger.Debug("New succeeded Collect work received: ", string(d.Body))
 
 	// Retrieve work bytes from redis
 	workSucceededBytes, err := commonGlobal.Redis.Get(context.Background(), string(d.Body)).Bytes()
-	commonGlobal.Redis.Del(context.Background(), string(d.Body))
-	if err != nil {
+	if errors.Is(err, redis.Nil) {
+		// Results are kept until processed, so it must be a duplicate (or expired)
+		global.Logger.Warnf("Succeeded work result %s already processed", string(d.Body))
+		ackFeedCollectResult(string(d.Body))
+		return nil
+	} else if err != nil {
 		global.Logger.Errorf("Failed to retrieve succeeded work result from redis with error: %s", err.Error())
-		return
+		return err
 	}
 
 	var workSucceeded commonTypes.WorkSucceeded
 	if err := json.Unmarshal(workSucceededBytes, &workSucceeded); err != nil {
 		global.Logger.Error("Unable to parse succeeded work: ", string(d.Body))
+		return fmt.Errorf("%w: %v", errUnrecoverable, err)
 	} else {
 		// Parse successfully
 		// Parse feeds
@@ -113,38 +172,7 @@ func feedCollectHandleSucceeded(d *amqp.Delivery) {
 		// Sort feeds by publish time (ASC)
 		sort.Sort(feeds)
 
-		// Find account
 		var account models.Account
-		// Ensure account exists
-		if err := global.DB.First(&account, workSucceeded.AccountID).Error; errors.Is(err, gorm.ErrRecordNotFound) {
-			// Account is not valid anymore
-			global.Logger.Errorf("Failed to find matching account with id: %d", workSucceeded.AccountID)
-			return
-		} else if err != nil {
-			// Other errors (if any)
-			global.Logger.Errorf("Failed to find matching account with error: %v", err)
-			return
-		}
-
-		// Update account
-		var interv time.Duration
-		if len(feeds) > 0 {
-			interv = workSucceeded.NewInterval
-		} else {
-			// workSucceeded.NewInterval == account.NextUpdate - account.LastUpdated
-			interv = workSucceeded.SucceededAt.Sub(account.LastUpdated) // So we can double the interval as nothing found since then
-		}
-		platform := commonConsts.SUPPORTED_PLATFORM[workSucceeded.Platform]
-		if interv < platform.MinRefreshGap {
-			interv = platform.MinRefreshGap
-		} else if interv > platform.MaxRefreshGap {
-			interv = platform.MaxRefreshGap
-		}
-		account.LastUpdated = workSucceeded.SucceededAt
-		account.UpdateInterval = interv
-		account.NextUpdate = account.LastUpdated.Add(account.UpdateInterval)
-		account.FeedsCount += uint(len(feeds))
-		// NotesCount increase need feeds to be published on chain
 
 		// Update character
 		//var character models.Character
@@ -152,16 +180,41 @@ func feedCollectHandleSucceeded(d *amqp.Delivery) {
 
 		if err := global.DB.Transaction(func(tx *gorm.DB) error {
 			// do some database operations in the transaction (use 'tx' from this point, not 'db')
+			if err := markWorkProcessed(tx, &workSucceeded.WorkDispatched); err != nil {
+				return err
+			}
+
+			// Find account
+			if err := lockAccount(tx, workSucceeded.AccountID, &account); err != nil {
+				return err
+			}
+
+			// Update account
+			account.LastUpdated = workSucceeded.SucceededAt
+			utils.AccountCollectSucceeded(&account, workSucceeded.Gap, workSucceeded.SucceededAt)
+			// NotesCount increase need feeds to be published on chain
+
 			if len(feeds) > 0 {
 
-				// Insert feeds
-				if err := tx.Scopes(models.FeedTable(models.Feed{
-					Feed: types.Feed{
-						Platform: workSucceeded.Platform,
-					},
-				})).Create(&feeds).Error; err != nil {
-					return err
+				// Insert feeds, skip those already collected
+				var insertedFeeds models.FeedsArray
+				for _, feed := range feeds {
+					result := tx.Scopes(models.FeedTable(models.Feed{
+						Feed: types.Feed{
+							Platform: workSucceeded.Platform,
+						},
+					}), models.FeedSkipDuplicated).Create(&feed)
+					if result.Error != nil {
+						return result.Error
+					} else if result.RowsAffected > 0 {
+						insertedFeeds = append(insertedFeeds, feed)
+					}
+				}
+				if len(insertedFeeds) < len(feeds) {
+					global.Logger.Warnf("%d duplicated feeds skipped for account #%d", len(feeds)-len(insertedFeeds), account.ID)
 				}
+				feeds = insertedFeeds
+				account.FeedsCount += uint(len(feeds))
 
 				// Insert medias (Can only be processed here because we need feed IDs to identify them)
 				mediaMap := make(map[string]models.Media)
@@ -236,6 +289,13 @@ func feedCollectHandleSucceeded(d *amqp.Delivery) {
 				}
 			}
 
+			// Schedule with feeds inserted only, those collected before are already counted
+			var publishedAts []time.Time
+			for _, feed := range feeds {
+				publishedAts = append(publishedAts, feed.PublishedAt)
+			}
+			utils.AccountScheduleNextUpdate(&account, publishedAts, workSucceeded.SucceededAt)
+
 			// Update account
 			if err := tx.Save(&account).Error; err != nil {
 				return err
@@ -248,10 +308,22 @@ func feedCollectHandleSucceeded(d *amqp.Delivery) {
 
 			// return nil will commit the whole transaction
 			return nil
-		}); err != nil {
+		}); errors.Is(err, errWorkProcessed) {
+			global.Logger.Warnf("Succeeded work result %s already processed", string(d.Body))
+			ackFeedCollectResult(string(d.Body))
+			return nil
+		} else if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Account is not valid anymore
+			global.Logger.Errorf("Failed to find matching account with id: %d", workSucceeded.AccountID)
+			ackFeedCollectResult(string(d.Body))
+			return nil
+		} else if err != nil {
 			global.Logger.Error("Unable to save feeds: ", workSucceeded)
+			return err
 		} else {
 			// Succeeded
+			ackFeedCollectResult(string(d.Body))
+
 			// Clear cache
 			accountsCacheKey := fmt.Sprintf("%s:%s:%s", consts.CACHE_PREFIX, "accounts:list", account.CrossbellCharacterID)
 			feedsCacheKey := fmt.Sprintf("%s:%s:%d", consts.CACHE_PREFIX, "feeds", account.ID)
@@ -267,15 +339,111 @@ func feedCollectHandleSucceeded(d *amqp.Delivery) {
 			}
 		}
 	}
+
+	return nil
+}
+
+// lockAccount : Load account in transaction, locked until committed so results of the same account don't overwrite each other
+func lockAccount(tx *gorm.DB, accountID uint, account *models.Account) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(account, accountID).Error
+}
+
+// markWorkProcessed : Record work as processed in transaction, or errWorkProcessed if it was processed before
+func markWorkProcessed(tx *gorm.DB, work *commonTypes.WorkDispatched) error {
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ProcessedWork{
+		AccountID:   work.AccountID,
+		DispatchAt:  work.DispatchAt,
+		ProcessedAt: time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	} else if result.RowsAffected == 0 {
+		return errWorkProcessed
+	}
+	return nil
 }
 
-func feedCollectHandleFailed(d *amqp.Delivery) {
+// ackFeedCollectResult : Remove processed result from store, or it would be replayed by reconciliation
+func ackFeedCollectResult(key string) {
+	if err := commonResults.Ack(context.Background(), commonGlobal.Redis, key); err != nil {
+		global.Logger.Errorf("Failed to remove processed work result %s with error: %s", key, err.Error())
+	}
+}
+
+func feedCollectHandleFailed(d *amqp.Delivery) error {
 	global.Logger.Warn("New failed Collect work received: ", string(d.Body))
 
 	var workFailed commonTypes.WorkFailed
 	if err := json.Unmarshal(d.Body, &workFailed); err != nil {
 		global.Logger.Error("Unable to parse failed work: ", string(d.Body))
+		return fmt.Errorf("%w: %v", errUnrecoverable, err)
 	} else {
 		global.Logger.Warn("Work failed for: ", workFailed)
 	}
+
+	var account models.Account
+	if err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := markWorkProcessed(tx, &workFailed.WorkDispatched); err != nil {
+			return err
+		}
+		if err := lockAccount(tx, workFailed.AccountID, &account); err != nil {
+			return err
+		}
+
+		utils.AccountCollectFailed(&account, &workFailed)
+		if account.IsCollectSuspended {
+			global.Logger.Warnf("Account #%d (%s@%s) collect suspended: %s", account.ID, account.Username, account.Platform, account.CollectSuspendMessage)
+		}
+
+		return tx.Save(&account).Error
+	}); errors.Is(err, errWorkProcessed) {
+		global.Logger.Warnf("Failed work for account #%d already processed", workFailed.AccountID)
+		return nil
+	} else if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Account is not valid anymore
+		global.Logger.Errorf("Failed to find matching account with id: %d", workFailed.AccountID)
+		return nil
+	} else if err != nil {
+		global.Logger.Errorf("Failed to save failure for account #%d with error: %s", workFailed.AccountID, err.Error())
+		return err
+	}
+
+	// Clear cache
+	accountsCacheKey := fmt.Sprintf("%s:%s:%s", consts.CACHE_PREFIX, "accounts:list", account.CrossbellCharacterID)
+	commonGlobal.Redis.Del(context.Background(), accountsCacheKey) // To flush failure records
+
+	return nil
+}
+
+// feedCollectHandleExpired : Work discarded by worker, reschedule the account unless a newer work has been dispatched
+func feedCollectHandleExpired(d *amqp.Delivery) error {
+	global.Logger.Debug("New expired Collect work received: ", string(d.Body))
+
+	var workExpired commonTypes.WorkFailed
+	if err := json.Unmarshal(d.Body, &workExpired); err != nil {
+		global.Logger.Error("Unable to parse expired work: ", string(d.Body))
+		return fmt.Errorf("%w: %v", errUnrecoverable, err)
+	}
+
+	if err := global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := markWorkProcessed(tx, &workExpired.WorkDispatched); err != nil {
+			return err
+		}
+		// Unless newer work dispatched (or scheduled), it takes over
+		result := tx.Model(&models.Account{}).
+			Where("id = ? AND next_update <= ?", workExpired.AccountID, workExpired.DropAfter).
+			Update("next_update", time.Now())
+		if result.Error == nil && result.RowsAffected > 0 {
+			global.Logger.Debugf("Reschedule account #%d (%s@%s) as work expired: %s", workExpired.AccountID, workExpired.Username, workExpired.Platform, workExpired.ErrorReason)
+		}
+		return result.Error
+	}); errors.Is(err, errWorkProcessed) {
+		global.Logger.Warnf("Expired work for account #%d already processed", workExpired.AccountID)
+		return nil
+	} else if err != nil {
+		global.Logger.Errorf("Failed to reschedule account #%d with error: %s", workExpired.AccountID, err.Error())
+		return err
+	}
+
+	return nil
 }
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/leader"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+)
+
+// isLeading : Scheduler jobs only run on leader, confirm with fencing token before each run
+func isLeading(job string) bool {
+	token, ok := leader.Token()
+	if !ok {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.LEADER_RENEW_INTERVAL)
+	defer cancel()
+	if err := leader.Fence(ctx, token); err != nil {
+		global.Logger.Warnf("Skip %s job as leadership (token %d) not confirmed: %s", job, token, err.Error())
+		return false
+	}
+
+	return true
+}
+
+// startJob : Run job in background if leading, shutdown waits for it to finish
+func startJob(job string, fn func()) {
+	if !isLeading(job) || !shutdown.TryTrack() {
+		return
+	}
+
+	go func() {
+		defer shutdown.Done()
+		fn()
+	}()
+}
@@ -3,12 +3,13 @@ package jobs
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-func prepareFeedCollectQueue(queueName string, notifyClose chan *amqp.Error) (*amqp.Channel, *amqp.Queue) {
+func prepareFeedCollectQueue(notifyClose chan *amqp.Error) *amqp.Channel {
 
-	global.Logger.Debugf("Preparing channel & queue for %s ...", queueName)
+	global.Logger.Debug("Preparing channel & queues for feed collect ...")
 
 	// Prepare channel
 
@@ -18,19 +19,11 @@ func prepareFeedCollectQueue(queueName string, notifyClose chan *amqp.Error) (*a
 	}
 	ch.NotifyClose(notifyClose) // Let caller handle reconnect
 
-	// Prepare queue
+	// Prepare queues (same as worker)
 
-	q, err := ch.QueueDeclare(
-		queueName,
-		false,
-		false,
-		false,
-		false,
-		nil,
-	)
-	if err != nil {
-		global.Logger.Fatalf("Failed to prepare queue %s with error: %s", queueName, err.Error())
+	if err := commonMQ.DeclareFeedCollectTopology(ch); err != nil {
+		global.Logger.Fatalf("Failed to prepare feed collect queues with error: %s", err.Error())
 	}
 
-	return ch, &q
+	return ch
 }
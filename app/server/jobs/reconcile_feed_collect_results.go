@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/joblock"
+	"github.com/Crossbell-Box/OperatorSync/app/server/models"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	commonResults "github.com/Crossbell-Box/OperatorSync/common/results"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"time"
+)
+
+func FeedCollectStartReconcileResults() {
+
+	global.Logger.Debug("Feed Collect results start reconciling...")
+	go func() {
+		t := time.NewTicker(consts.JOBS_INTERVAL_RECONCILE_RESULTS)
+		for {
+			// Waiting for connection
+			for {
+				time.Sleep(commonConsts.MQSETTINGS_ReconnectDelay)
+				if commonGlobal.MQ != nil {
+					break
+				}
+			}
+			notifyClose := make(chan *amqp.Error)
+			ch := prepareFeedCollectQueue(notifyClose)
+			if err := ch.Confirm(false); err != nil {
+				global.Logger.Fatalf("Failed to enable publisher confirms with error: %s", err.Error())
+			}
+			isPendingRestart := false
+			for {
+				if isPendingRestart {
+					break
+				}
+				select {
+				case <-t.C:
+					startJob("ReconcileResults", func() {
+						reconcileFeedCollectResults(ch)
+					})
+				case <-shutdown.Stopping():
+					// No more runs, channel is kept open for runs in progress
+					return
+				case err := <-notifyClose:
+					if err != nil {
+						global.Logger.Errorf("MQ channel closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
+						isPendingRestart = true
+					}
+				}
+			}
+		}
+	}()
+}
+
+func reconcileFeedCollectResults(ch *amqp.Channel) {
+	if err := joblock.Run(consts.JOB_RECONCILE_RESULTS, consts.JOBS_LOCK_TTL, func(ctx context.Context) error {
+		if err := pruneProcessedWorks(ctx); err != nil {
+			return err
+		}
+		if err := commonResults.PruneArchived(ctx, commonGlobal.Redis); err != nil {
+			return err
+		}
+		return replayStaleFeedCollectResults(ctx, ch)
+	}); errors.Is(err, joblock.ErrLocked) {
+		global.Logger.Warn("Another ReconcileResults work is running, skip this.")
+	} else if err != nil {
+		global.Logger.Errorf("ReconcileResults work failed with error: %s", err.Error())
+	}
+}
+
+// replayStaleFeedCollectResults : Results stored by workers but not processed for long (e.g. report lost),
+// publish them to retrieve queue again, or archive them if replayed too many times (e.g. keep failing)
+func replayStaleFeedCollectResults(ctx context.Context, ch *amqp.Channel) error {
+	keys, err := commonResults.ListStale(ctx, commonGlobal.Redis, time.Now().Add(-consts.RECONCILE_RESULTS_STALE_AFTER), consts.RECONCILE_RESULTS_BATCH_SIZE)
+	if err != nil {
+		return err
+	}
+
+	replayed := 0
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			// Lock lost, let the new holder continue
+			return err
+		}
+
+		if exists, err := commonGlobal.Redis.Exists(ctx, key).Result(); err != nil {
+			return err
+		} else if exists == 0 {
+			// Result gone, only index left
+			ackFeedCollectResult(key)
+			continue
+		}
+
+		if replays, err := commonResults.Replays(ctx, commonGlobal.Redis, key); err != nil {
+			return err
+		} else if replays >= consts.RECONCILE_RESULTS_MAX_REPLAYS {
+			if err := commonResults.Archive(ctx, commonGlobal.Redis, key); err != nil {
+				return err
+			}
+			global.Logger.Errorf("Work result %s still not processed after replayed %d times, archived", key, replays)
+			continue
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, commonConsts.MQSETTINGS_PublishTimeOut)
+		err := commonMQ.PublishConfirmed(
+			publishCtx,
+			ch,
+			"",
+			commonConsts.MQSETTINGS_FeedCollectRetrieveQueueName,
+			amqp.Publishing{
+				DeliveryMode: amqp.Persistent,
+				ContentType:  "text/plain",
+				Headers: amqp.Table{
+					commonConsts.MQSETTINGS_FeedCollectIdentifierField: commonConsts.MQSETTINGS_FeedCollectSucceededIdentifier,
+				},
+				Body: []byte(key),
+			},
+		)
+		cancel()
+		if err != nil {
+			global.Logger.Errorf("Failed to replay work result %s with error: %s", key, err.Error())
+			return err
+		}
+
+		// Wait for another while before next replay
+		if err := commonResults.Touch(ctx, commonGlobal.Redis, key); err != nil {
+			global.Logger.Errorf("Failed to touch work result %s with error: %s", key, err.Error())
+		}
+		replayed++
+	}
+
+	if replayed > 0 {
+		global.Logger.Warnf("Replayed %d stale work results", replayed)
+	}
+
+	return nil
+}
+
+// pruneProcessedWorks : Works processed long ago won't be delivered again
+func pruneProcessedWorks(ctx context.Context) error {
+	return global.DB.WithContext(ctx).
+		Where("processed_at < ?", time.Now().Add(-consts.RECONCILE_PROCESSED_WORKS_TTL)).
+		Delete(&models.ProcessedWork{}).Error
+}
@@ -1,13 +1,16 @@
 package jobs
 
 import (
+	"context"
 	"errors"
 	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	"github.com/Crossbell-Box/OperatorSync/app/server/joblock"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
 	"github.com/Crossbell-Box/OperatorSync/app/server/utils"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"gorm.io/gorm"
 	"net/http"
 	"sort"
@@ -20,21 +23,15 @@ func ResumePausedAccounts() {
 		t := time.NewTicker(consts.JOBS_INTERVAL_RESUME_PAUSED_ACCOUNTS)
 		for {
 			select {
+			case <-shutdown.Stopping():
+				return
 			case <-t.C:
-				go TryToResumeAllPausedAccounts()
+				startJob("ResumePausedAccounts", TryToResumeAllPausedAccounts)
 			}
 		}
 	}()
 }
 
-var (
-	_isResumeWorkProcessing bool
-)
-
-func init() {
-	_isResumeWorkProcessing = false
-}
-
 func TryToResumeAllPausedAccounts() {
 
 	if config.Config.HeartBeatWebhooks.AccountResume != "" {
@@ -46,29 +43,29 @@ func TryToResumeAllPausedAccounts() {
 		}
 	}
 
-	config.Status.Jobs.ResumePausedAccountsLastRun = time.Now()
-
-	if _isResumeWorkProcessing {
+	if err := joblock.Run(consts.JOB_RESUME_PAUSED_ACCOUNTS, consts.JOBS_LOCK_TTL, resumeAllPausedAccounts); errors.Is(err, joblock.ErrLocked) {
 		// No need to start another one, skip
 		global.Logger.Warn("Another ResumePausedAccounts work is running, skip this.")
-		return
+	} else if err != nil {
+		global.Logger.Errorf("ResumePausedAccounts work failed with error: %s", err.Error())
 	}
+}
 
-	// Set busy flag
-	_isResumeWorkProcessing = true
-	global.Logger.Debugf("Lock busy flag for account resume work.")
-	defer func() {
-		global.Logger.Debugf("Unlock busy flag for account resume work.")
-		_isResumeWorkProcessing = false
-	}()
+func resumeAllPausedAccounts(ctx context.Context) error {
 
 	global.Logger.Debug("Start trying to resume all paused accounts...")
 
 	var pausedAccounts []models.Account
 
-	global.DB.Find(&pausedAccounts, "is_onchain_paused = ?", true)
+	if err := global.DB.Find(&pausedAccounts, "is_onchain_paused = ?", true).Error; err != nil {
+		return err
+	}
 
 	for _, pa := range pausedAccounts {
+		if err := ctx.Err(); err != nil {
+			// Lock lost, let the new holder continue
+			return err
+		}
 		if tryToResumeOnePausedAccount(&pa) {
 			// Recovered
 			global.Logger.Debugf("Account #%d (%s@%s) recovered", pa.ID, pa.Username, pa.Platform)
@@ -80,6 +77,8 @@ func TryToResumeAllPausedAccounts() {
 	}
 
 	global.Logger.Debug("All paused accounts checked, and already tried best to resume them.")
+
+	return nil
 }
 
 func tryToResumeOnePausedAccount(account *models.Account) bool {
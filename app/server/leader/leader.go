@@ -0,0 +1,225 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotLeader : Lease is lost or held by another server
+var ErrNotLeader = errors.New("not leader")
+
+type Lease struct {
+	ID    string `json:"id"`
+	Token int64  `json:"token"`
+}
+
+type state struct {
+	lease      Lease
+	isLeader   bool
+	since      time.Time // Leader since
+	validUntil time.Time // Lease might be taken by others after this
+}
+
+var (
+	nodeID   string
+	current  state
+	mu       sync.RWMutex
+	stop     = make(chan struct{})
+	stopOnce sync.Once
+)
+
+// acquireScript : Take the lease if free, with a new fencing token
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	local token = redis.call("INCR", KEYS[2])
+	redis.call("SET", KEYS[1], ARGV[1] .. "|" .. token, "PX", ARGV[2])
+	return token
+end
+return 0
+`)
+
+// renewScript : Extend the lease only if still held by us
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseScript : Delete the lease only if still held by us
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Start : Keep campaigning for leadership in background
+func Start(id string) {
+	nodeID = id
+	go func() {
+		campaign()
+		t := time.NewTicker(consts.LEADER_RENEW_INTERVAL)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				campaign()
+			}
+		}
+	}()
+}
+
+// Resign : Stop campaigning and release the lease (if held), so another server takes over soon
+func Resign(ctx context.Context) error {
+	stopOnce.Do(func() {
+		close(stop)
+	})
+
+	mu.Lock()
+	s := current
+	current = state{}
+	mu.Unlock()
+
+	if !s.isLeader {
+		return nil
+	}
+
+	global.Logger.Infof("Resigning leader lease %s", s.lease.String())
+	return releaseScript.Run(ctx, commonGlobal.Redis, []string{consts.LEADER_LEASE_KEY}, s.lease.String()).Err()
+}
+
+func campaign() {
+	ctx, cancel := context.WithTimeout(context.Background(), consts.LEADER_RENEW_INTERVAL)
+	defer cancel()
+
+	mu.RLock()
+	s := current
+	mu.RUnlock()
+
+	startAt := time.Now()
+	if s.isLeader {
+		ok, err := renewScript.Run(ctx, commonGlobal.Redis,
+			[]string{consts.LEADER_LEASE_KEY},
+			s.lease.String(), consts.LEADER_LEASE_TTL.Milliseconds(),
+		).Bool()
+		if err != nil {
+			// Keep leadership until lease expires, maybe we can renew next time
+			global.Logger.Errorf("Failed to renew leader lease with error: %s", err.Error())
+			if !startAt.Before(s.validUntil) {
+				global.Logger.Warnf("Leader lease of %s expired", s.lease.String())
+				s = state{}
+			}
+		} else if !ok {
+			global.Logger.Warnf("Leader lease of %s lost", s.lease.String())
+			s = state{}
+		} else {
+			s.validUntil = startAt.Add(consts.LEADER_LEASE_TTL)
+		}
+	} else {
+		token, err := acquireScript.Run(ctx, commonGlobal.Redis,
+			[]string{consts.LEADER_LEASE_KEY, consts.LEADER_TOKEN_KEY},
+			nodeID, consts.LEADER_LEASE_TTL.Milliseconds(),
+		).Int64()
+		if err != nil {
+			global.Logger.Errorf("Failed to acquire leader lease with error: %s", err.Error())
+		} else if token > 0 {
+			s = state{
+				lease:      Lease{ID: nodeID, Token: token},
+				isLeader:   true,
+				since:      startAt,
+				validUntil: startAt.Add(consts.LEADER_LEASE_TTL),
+			}
+			global.Logger.Infof("Elected as leader with fencing token %d", token)
+		}
+	}
+
+	mu.Lock()
+	select {
+	case <-stop:
+		// Resigned halfway
+	default:
+		current = s
+	}
+	mu.Unlock()
+}
+
+// IsLeader : Whether this server holds a valid lease now
+func IsLeader() bool {
+	_, ok := Token()
+	return ok
+}
+
+// Token : Fencing token of current lease, valid only if ok
+func Token() (int64, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if !current.isLeader || !time.Now().Before(current.validUntil) {
+		return 0, false
+	}
+	return current.lease.Token, true
+}
+
+// Since : When this server became leader
+func Since() time.Time {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current.since
+}
+
+// Fence : Check against redis that the lease with token is still ours, before doing anything exclusive
+func Fence(ctx context.Context, token int64) error {
+	lease, err := Current(ctx)
+	if err != nil {
+		return err
+	} else if lease == nil || lease.ID != nodeID || lease.Token != token {
+		return ErrNotLeader
+	}
+	return nil
+}
+
+// Current : The lease holder, nil if none
+func Current(ctx context.Context) (*Lease, error) {
+	val, err := commonGlobal.Redis.Get(ctx, consts.LEADER_LEASE_KEY).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return parseLease(val)
+}
+
+// NodeID : ID of this server
+func NodeID() string {
+	return nodeID
+}
+
+func (l Lease) String() string {
+	return fmt.Sprintf("%s|%d", l.ID, l.Token)
+}
+
+func parseLease(val string) (*Lease, error) {
+	sep := strings.LastIndex(val, "|")
+	if sep < 0 {
+		return nil, fmt.Errorf("invalid leader lease: %s", val)
+	}
+	token, err := strconv.ParseInt(val[sep+1:], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid leader lease token: %s", val)
+	}
+	return &Lease{
+		ID:    val[:sep],
+		Token: token,
+	}, nil
+}
@@ -0,0 +1,18 @@
+package leader
+
+import "testing"
+
+func TestParseLease(t *testing.T) {
+	lease := Lease{ID: "server|a", Token: 42}
+	parsed, err := parseLease(lease.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parsed != lease {
+		t.Errorf("expected %v, got %v", lease, *parsed)
+	}
+
+	if _, err = parseLease("server"); err == nil {
+		t.Error("lease without token should be invalid")
+	}
+}
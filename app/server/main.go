@@ -5,6 +5,8 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/inits"
 	commonInits "github.com/Crossbell-Box/OperatorSync/common/inits"
+	_ "github.com/Crossbell-Box/OperatorSync/common/platforms/builtin" // Register meta of built-in platforms
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"log"
 )
 
@@ -34,6 +36,11 @@ func main() {
 		global.Logger.Fatal("Failed to load redis: ", err.Error())
 	}
 
+	// Initialize platform settings
+	if err := commonInits.PlatformSettings(config.Config.PlatformSettingsFile, global.Logger); err != nil {
+		global.Logger.Fatal("Failed to load platform settings: ", err.Error())
+	}
+
 	// Initialize MQ
 	if err := commonInits.MQ(config.Config.MQConnString, global.Logger); err != nil {
 		global.Logger.Fatal("Failed to load MQ: ", err.Error())
@@ -44,6 +51,11 @@ func main() {
 		global.Logger.Fatal("Failed to connect to worker RPC: ", err.Error())
 	}
 
+	// Initialize leader election
+	if err := inits.Leader(); err != nil {
+		global.Logger.Fatal("Failed to start leader election: ", err.Error())
+	}
+
 	// Initialize jobs
 	if err := inits.Jobs(); err != nil {
 		global.Logger.Fatal("Failed to start jobs: ", err.Error())
@@ -56,8 +68,9 @@ func main() {
 
 	// Start
 	global.Logger.Info("Service starting...")
-	if err := engine.Run(); err != nil {
-		global.Logger.Fatal("Failed to start service:", err.Error())
-	}
+	inits.HTTP(engine)
+
+	// Keep process running till SIGTERM, then finish works in progress
+	shutdown.Wait(global.Logger, config.Config.ShutdownTimeout)
 
 }
@@ -15,4 +15,7 @@ type Account struct {
 
 	types.Account
 	types.OnChainStatusManageForAccount
+	types.CollectStatusManageForAccount
+
+	ActivityModel types.ActivityModel `gorm:"type:text" json:"-"` // For scheduling, summarized in API
 }
@@ -1,8 +1,12 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -10,6 +14,7 @@ type Feed struct {
 	// Database related fields
 	ID        uint      `gorm:"primarykey" json:"-"`
 	CreatedAt time.Time `gorm:"index" json:"-"`
+	DedupKey  string    `gorm:"not null;default:''" json:"-"` // Set on create, see FeedDedupKey
 
 	types.Feed
 	types.OnChainData
@@ -17,10 +22,53 @@ type Feed struct {
 
 const feedPrefix = "feed_"
 
+func FeedTableName(f Feed) string {
+	return feedPrefix + f.Platform
+}
+
 func FeedTable(f Feed) func(tx *gorm.DB) *gorm.DB {
 	return func(tx *gorm.DB) *gorm.DB {
-		return tx.Table(feedPrefix + f.Platform)
+		return tx.Table(FeedTableName(f))
+	}
+}
+
+// FeedUniqueKeyIndex : Feeds are unique by dedup key for each account
+func FeedUniqueKeyIndex(f Feed) string {
+	return fmt.Sprintf("idx_%s_account_dedup_key", FeedTableName(f))
+}
+
+// FeedDedupKey : GUID, or link if no GUID, or hash of publish time, title and content if neither provided
+func FeedDedupKey(f *types.Feed) string {
+	if f.GUID != "" {
+		return f.GUID
+	} else if f.Link != "" {
+		return "link:" + f.Link
 	}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d\n%s\n%s", f.PublishedAt.Unix(), f.Title, f.Content)))
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+// FeedDedupKeySQL : Same as FeedDedupKey, for feeds stored before dedup key is introduced
+const FeedDedupKeySQL = `CASE
+	WHEN COALESCE(guid, '') <> '' THEN guid
+	WHEN COALESCE(link, '') <> '' THEN 'link:' || link
+	ELSE 'sha256:' || encode(sha256(convert_to(
+		floor(extract(epoch FROM published_at))::bigint || E'\n' || COALESCE(title, '') || E'\n' || COALESCE(content, ''),
+		'UTF8'
+	)), 'hex')
+END`
+
+func (f *Feed) BeforeCreate(_ *gorm.DB) error {
+	f.DedupKey = FeedDedupKey(&f.Feed)
+	return nil
+}
+
+// FeedSkipDuplicated : Insert feeds not collected before (by dedup key), others are ignored
+func FeedSkipDuplicated(tx *gorm.DB) *gorm.DB {
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_id"}, {Name: "dedup_key"}},
+		DoNothing: true,
+	})
 }
 
 type FeedsArray []Feed
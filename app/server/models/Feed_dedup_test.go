@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/server/types"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedDedupKey(t *testing.T) {
+	publishedAt := time.Date(2023, 5, 1, 8, 0, 0, 0, time.UTC)
+
+	if key := FeedDedupKey(&types.Feed{RawFeed: commonTypes.RawFeed{GUID: "ep3", Link: "https://example.com/ep3"}}); key != "ep3" {
+		t.Errorf("should be GUID, got %s", key)
+	}
+	if key := FeedDedupKey(&types.Feed{RawFeed: commonTypes.RawFeed{Link: "https://example.com/ep3"}}); key != "link:https://example.com/ep3" {
+		t.Errorf("should fall back to link, got %s", key)
+	}
+
+	hashed := FeedDedupKey(&types.Feed{RawFeed: commonTypes.RawFeed{Title: "Hello", PublishedAt: publishedAt}})
+	if !strings.HasPrefix(hashed, "sha256:") {
+		t.Errorf("should fall back to hash, got %s", hashed)
+	}
+	if FeedDedupKey(&types.Feed{RawFeed: commonTypes.RawFeed{Title: "Hello", PublishedAt: publishedAt}}) != hashed {
+		t.Error("hash should be stable")
+	}
+	if FeedDedupKey(&types.Feed{RawFeed: commonTypes.RawFeed{Title: "Hello again", PublishedAt: publishedAt}}) == hashed {
+		t.Error("hash should differ for different feeds")
+	}
+}
+
+// Requires a Postgres database, e.g. TEST_DATABASE_CONNECTION_STRING="host=localhost user=postgres dbname=test"
+func TestFeedSkipDuplicated(t *testing.T) {
+	connString, exist := os.LookupEnv("TEST_DATABASE_CONNECTION_STRING")
+	if !exist {
+		t.Skip("TEST_DATABASE_CONNECTION_STRING not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(connString), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	feedWithPlatform := Feed{Feed: types.Feed{Platform: "dedup_test"}}
+	feedTable := FeedTableName(feedWithPlatform)
+	t.Cleanup(func() {
+		_ = db.Migrator().DropTable(feedTable)
+	})
+	if err := db.Scopes(FeedTable(feedWithPlatform)).AutoMigrate(&feedWithPlatform); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE UNIQUE INDEX %s ON %s (account_id, dedup_key)",
+		FeedUniqueKeyIndex(feedWithPlatform), feedTable,
+	)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	publishedAt := time.Date(2023, 5, 1, 8, 0, 0, 0, time.UTC)
+	rawFeeds := []commonTypes.RawFeed{
+		{GUID: "ep3", Title: "With GUID", PublishedAt: publishedAt},
+		{Link: "https://example.com/ep2", Title: "With link only", PublishedAt: publishedAt},
+		{Title: "With nothing", PublishedAt: publishedAt},
+	}
+
+	// Same result processed twice
+	for i := 0; i < 2; i++ {
+		for _, rawFeed := range rawFeeds {
+			feed := Feed{Feed: types.Feed{AccountID: 1, Platform: "dedup_test", RawFeed: rawFeed}}
+			result := db.Scopes(FeedTable(feedWithPlatform), FeedSkipDuplicated).Create(&feed)
+			if result.Error != nil {
+				t.Fatal(result.Error)
+			}
+			if inserted := result.RowsAffected > 0; inserted != (i == 0) {
+				t.Errorf("feed %q inserted: %v in round %d", rawFeed.Title, inserted, i+1)
+			}
+		}
+	}
+
+	var count int64
+	if err := db.Table(feedTable).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != int64(len(rawFeeds)) {
+		t.Errorf("should have %d feeds without duplicated ones, got %d", len(rawFeeds), count)
+	}
+
+	// Keys of existing feeds are filled the same way
+	var keys []string
+	if err := db.Table(feedTable).Order("id").Pluck(FeedDedupKeySQL, &keys).Error; err != nil {
+		t.Fatal(err)
+	}
+	for i, rawFeed := range rawFeeds {
+		if expected := FeedDedupKey(&types.Feed{RawFeed: rawFeed}); keys[i] != expected {
+			t.Errorf("dedup key in SQL %s differs from %s", keys[i], expected)
+		}
+	}
+}
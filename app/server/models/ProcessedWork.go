@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// ProcessedWork : Results of works processed, so duplicated deliveries can be ignored
+type ProcessedWork struct {
+	ID          uint      `gorm:"primarykey"`
+	AccountID   uint      `gorm:"uniqueIndex:idx_processed_works_work"`
+	DispatchAt  time.Time `gorm:"uniqueIndex:idx_processed_works_work"`
+	ProcessedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/handlers/admin"
+	"github.com/gin-gonic/gin"
+)
+
+func AdminEndpoints(rg *gin.RouterGroup) {
+	rg.GET("/platforms", admin.ListPlatformSettings)
+	rg.PUT("/platforms/:platform", admin.SetPlatformSettings)
+	rg.DELETE("/platforms/:platform", admin.ResetPlatformSettings)
+	rg.GET("/dead-letters", admin.ListDeadLetters)
+	rg.POST("/dead-letters/replay", admin.ReplayDeadLetters)
+}
@@ -11,6 +11,7 @@ func PublicEndpoints(rg *gin.RouterGroup) {
 
 	// Health Check
 	rg.GET("/healthcheck", public.HealthCheck)
+	rg.GET("/ready", public.Ready)
 
 	// List platforms
 	rg.GET("/platforms", public.ListPlatforms)
@@ -1,7 +1,9 @@
 package routers
 
 import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/config"
 	"github.com/Crossbell-Box/OperatorSync/app/server/middleware"
+	commonMiddleware "github.com/Crossbell-Box/OperatorSync/common/middleware"
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,4 +24,10 @@ func R(e *gin.Engine) {
 	v1Group := e.Group("/v1")
 	V1Endpoints(v1Group)
 
+	// Admin Endpoints
+	if config.Config.AdminToken != "" {
+		adminGroup := e.Group("/admin", commonMiddleware.AdminAuth(config.Config.AdminToken))
+		AdminEndpoints(adminGroup)
+	}
+
 }
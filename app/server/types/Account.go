@@ -26,9 +26,21 @@ type OnChainStatusManageForAccount struct {
 	OnChainPauseMessage string    `json:"onChain_pause_message"`
 }
 
+type CollectStatusManageForAccount struct {
+	ConsecutiveFailures   uint                      `json:"consecutive_failures" gorm:"default:0"`           // Any failure, for backoff
+	ConsecutiveFaults     uint                      `json:"consecutive_faults" gorm:"default:0"`             // Failures of account itself, for suspension
+	RecentFailures        CollectFailureRecordArray `gorm:"type:text" json:"recent_failures"`                // Latest at last
+	IsCollectSuspended    bool                      `json:"is_collect_suspended" gorm:"index;default:false"` // Not the same as on-chain pause
+	CollectSuspendedAt    time.Time                 `json:"collect_suspended_at"`
+	CollectSuspendMessage string                    `json:"collect_suspend_message"`
+	UnresolvedGaps        FeedGapRecordArray        `gorm:"type:text" json:"unresolved_gaps"` // Latest at last
+}
+
 type AccountWithAdditionalPropsForListResponse struct {
 	Account
 	OnChainStatusManageForAccount
+	CollectStatusManageForAccount
 
-	IsMetadataCorrect bool `json:"is_metadata_correct"`
+	IsMetadataCorrect bool            `json:"is_metadata_correct"`
+	Activity          ActivitySummary `json:"activity"`
 }
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const ActivityModelBuckets = 7 * 24 // Hours of week, from Sunday 00:00 (UTC)
+
+// ActivityModel : Posting pattern of an account, older posts weigh less with exponential decay
+type ActivityModel struct {
+	Histogram  [ActivityModelBuckets]float64 `json:"histogram"`    // Decayed posts of each hour of week
+	Samples    uint                          `json:"samples"`      // Posts observed in total
+	LastPostAt time.Time                     `json:"last_post_at"` // Latest post observed
+	StartedAt  time.Time                     `json:"started_at"`   // Since first observation or oldest post
+	UpdatedAt  time.Time                     `json:"updated_at"`   // Histogram decayed until
+}
+
+func (am *ActivityModel) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		// Column added to existing rows
+		*am = ActivityModel{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), am)
+	case []byte:
+		return json.Unmarshal(v, am)
+	default:
+		return fmt.Errorf("unsupported activity model type: %T", src)
+	}
+}
+
+func (am ActivityModel) Value() (driver.Value, error) {
+	val, err := json.Marshal(&am)
+	return string(val), err
+}
+
+// ActivitySummary : Human-readable part of ActivityModel, for account API
+type ActivitySummary struct {
+	Samples           uint       `json:"samples"`
+	PostsPerWeek      float64    `json:"posts_per_week"`      // Estimated with decay
+	ActiveHours       []int      `json:"active_hours"`        // Most active hours of week (UTC, 0 is Sunday 00:00), most active first
+	LastPostAt        time.Time  `json:"last_post_at"`        // Latest post observed
+	PredictedNextPost *time.Time `json:"predicted_next_post"` // Nil if not expected within a week
+	NextPoll          time.Time  `json:"next_poll"`
+}
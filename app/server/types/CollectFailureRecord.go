@@ -0,0 +1,38 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CollectFailureRecord : Shown to users with their accounts, so stack traces of panics are left in logs
+type CollectFailureRecord struct {
+	ErrorCode   uint      `json:"error_code"`
+	ErrorReason string    `json:"error_reason"`
+	ErrorAt     time.Time `json:"error_at"`
+	ItemID      string    `json:"item_id,omitempty"` // Item in progress when failed, if known
+}
+
+type CollectFailureRecordArray []CollectFailureRecord
+
+func (fa *CollectFailureRecordArray) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		// Column added to existing rows
+		*fa = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), fa)
+	case []byte:
+		return json.Unmarshal(v, fa)
+	default:
+		return fmt.Errorf("unsupported collect failure records type: %T", src)
+	}
+}
+
+func (fa CollectFailureRecordArray) Value() (driver.Value, error) {
+	val, err := json.Marshal(&fa)
+	return string(val), err
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// FeedGapRecord : Feeds published between From and To might be missed
+type FeedGapRecord struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+type FeedGapRecordArray []FeedGapRecord
+
+func (ga *FeedGapRecordArray) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		// Column added to existing rows
+		*ga = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(v), ga)
+	case []byte:
+		return json.Unmarshal(v, ga)
+	default:
+		return fmt.Errorf("unsupported feed gap records type: %T", src)
+	}
+}
+
+func (ga FeedGapRecordArray) Value() (driver.Value, error) {
+	val, err := json.Marshal(&ga)
+	return string(val), err
+}
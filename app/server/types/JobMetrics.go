@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+type JobMetrics struct {
+	LastAttempt  time.Time     `json:"last_attempt"` // Triggered, whether ran or skipped
+	LastStart    time.Time     `json:"last_start"`
+	LastFinish   time.Time     `json:"last_finish"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastOutcome  string        `json:"last_outcome"`
+	LastError    string        `json:"last_error,omitempty"`
+	Running      bool          `json:"running"`
+	RunBy        string        `json:"run_by"` // Server ID
+	Runs         uint64        `json:"runs"`
+	Failures     uint64        `json:"failures"`
+	Skips        uint64        `json:"skips"` // Locked by another run
+}
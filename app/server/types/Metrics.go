@@ -1,6 +1,9 @@
 package types
 
-import "time"
+import (
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
 
 type AccountMetrics struct {
 	Total int64 `json:"total"`
@@ -10,6 +13,7 @@ type AccountMetrics struct {
 type PlatformMetrics struct {
 	Feed    int64          `json:"feed"`
 	Account AccountMetrics `json:"account"`
+	Workers int            `json:"workers"` // Live workers serving, works are not dispatched if none
 }
 
 type Metrics struct {
@@ -21,6 +25,8 @@ type Metrics struct {
 		Total int64 `json:"total"`
 		Valid int64 `json:"valid"` // With at least one account
 	} `json:"character"`
-	Account  AccountMetrics             `json:"account"`
-	Platform map[string]PlatformMetrics `json:"platform"`
+	Account  AccountMetrics                       `json:"account"`
+	Platform map[string]PlatformMetrics           `json:"platform"`
+	Worker   map[string]commonTypes.WorkerMetrics `json:"worker"` // Worker ID : metrics
+	Jobs     map[string]JobMetrics                `json:"jobs"`   // Job name : metrics
 }
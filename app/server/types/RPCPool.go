@@ -98,9 +98,17 @@ func (p *Pool) get() (*rpc.Client, error) {
 	}
 }
 
+// Close : Close idle clients, should be called after calls in progress finished
 func (p *Pool) Close() error {
-	// We're not doing a clean exit here. A clean exit here would require
-	// synchronization, which seems unnecessary for now. But, we should
-	// add one if required later.
-	return nil
+	var err error
+	for {
+		select {
+		case client := <-p.clients:
+			if closeErr := client.Close(); closeErr != nil {
+				err = closeErr
+			}
+		default:
+			return err
+		}
+	}
 }
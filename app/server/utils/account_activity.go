@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/models"
+	"github.com/Crossbell-Box/OperatorSync/app/server/types"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"math"
+	"sort"
+	"time"
+)
+
+// AccountScheduleNextUpdate : Learn from posts just collected, and poll when next post expected.
+// Caller should save the account.
+func AccountScheduleNextUpdate(account *models.Account, publishedAts []time.Time, at time.Time) {
+	observeActivity(&account.ActivityModel, publishedAts, at)
+
+	platform, _ := commonPlatforms.Meta(account.Platform)
+
+	var interv time.Duration
+	if account.ActivityModel.Samples == 0 {
+		// Nothing learnt yet, back off as nothing found
+		interv = 2 * account.UpdateInterval
+	} else if predicted, ok := predictActivity(&account.ActivityModel, at, platform.MaxRefreshGap); ok {
+		interv = predicted.Sub(at)
+	} else {
+		// Dormant
+		interv = platform.MaxRefreshGap
+	}
+
+	if interv < platform.MinRefreshGap {
+		interv = platform.MinRefreshGap
+	} else if interv > platform.MaxRefreshGap {
+		interv = platform.MaxRefreshGap
+	}
+	account.UpdateInterval = interv
+	account.NextUpdate = at.Add(interv)
+}
+
+// AccountActivitySummary : Summary of posting pattern for account API
+func AccountActivitySummary(account *models.Account, now time.Time) types.ActivitySummary {
+	model := &account.ActivityModel
+
+	summary := types.ActivitySummary{
+		Samples:    model.Samples,
+		LastPostAt: model.LastPostAt,
+		NextPoll:   account.NextUpdate,
+	}
+	if model.Samples == 0 {
+		return summary
+	}
+
+	rates := activityRates(model, now)
+	for _, rate := range rates {
+		summary.PostsPerWeek += rate
+	}
+
+	var hours []int
+	for hour, rate := range rates {
+		if rate > 0 {
+			hours = append(hours, hour)
+		}
+	}
+	sort.SliceStable(hours, func(i, j int) bool {
+		return rates[hours[i]] > rates[hours[j]]
+	})
+	if len(hours) > consts.ACTIVITY_SUMMARY_TOP_HOURS {
+		hours = hours[:consts.ACTIVITY_SUMMARY_TOP_HOURS]
+	}
+	summary.ActiveHours = hours
+
+	if predicted, ok := predictActivity(model, now, consts.ACTIVITY_PREDICT_HORIZON); ok {
+		summary.PredictedNextPost = &predicted
+	}
+
+	return summary
+}
+
+// observeActivity : Decay histogram to at, and add posts weighted by their age
+func observeActivity(model *types.ActivityModel, publishedAts []time.Time, at time.Time) {
+	if model.UpdatedAt.IsZero() {
+		model.StartedAt = at
+		model.UpdatedAt = at
+	}
+
+	if at.After(model.UpdatedAt) {
+		factor := activityDecay(at.Sub(model.UpdatedAt))
+		for i := range model.Histogram {
+			model.Histogram[i] *= factor
+		}
+		model.UpdatedAt = at
+	}
+
+	for _, publishedAt := range publishedAts {
+		if !publishedAt.After(time.Unix(0, 0)) || publishedAt.After(at) {
+			// Invalid time
+			continue
+		}
+
+		model.Histogram[hourOfWeek(publishedAt)] += activityDecay(at.Sub(publishedAt))
+		model.Samples++
+		if publishedAt.After(model.LastPostAt) {
+			model.LastPostAt = publishedAt
+		}
+		if publishedAt.Before(model.StartedAt) {
+			model.StartedAt = publishedAt
+		}
+	}
+}
+
+// predictActivity : Time when ACTIVITY_POSTS_PER_POLL posts expected since from, false if not within limit
+func predictActivity(model *types.ActivityModel, from time.Time, limit time.Duration) (time.Time, bool) {
+	rates := activityRates(model, from)
+
+	expected := 0.0
+	end := from.Add(limit)
+	for t := from; t.Before(end); {
+		next := t.Truncate(time.Hour).Add(time.Hour)
+		if next.After(end) {
+			next = end
+		}
+
+		rate := rates[hourOfWeek(t)] // Posts per hour
+		hours := next.Sub(t).Hours()
+		if rate > 0 && expected+rate*hours >= consts.ACTIVITY_POSTS_PER_POLL {
+			return t.Add(time.Duration((consts.ACTIVITY_POSTS_PER_POLL - expected) / rate * float64(time.Hour))), true
+		}
+
+		expected += rate * hours
+		t = next
+	}
+
+	return time.Time{}, false
+}
+
+// activityRates : Expected posts in each hour of week, estimated at now
+func activityRates(model *types.ActivityModel, now time.Time) [types.ActivityModelBuckets]float64 {
+	var rates [types.ActivityModelBuckets]float64
+
+	observed := now.Sub(model.StartedAt)
+	if observed < consts.ACTIVITY_MIN_OBSERVED {
+		observed = consts.ACTIVITY_MIN_OBSERVED
+	}
+	// Weeks observed, each weighs as decayed
+	halfLife := consts.ACTIVITY_HALF_LIFE.Hours()
+	weeks := halfLife / math.Ln2 * (1 - activityDecay(observed)) / (7 * 24)
+
+	factor := 1.0
+	if now.After(model.UpdatedAt) {
+		factor = activityDecay(now.Sub(model.UpdatedAt))
+	}
+
+	for i, weight := range model.Histogram {
+		rates[i] = weight * factor / weeks
+	}
+
+	return rates
+}
+
+func activityDecay(age time.Duration) float64 {
+	return math.Pow(0.5, age.Hours()/consts.ACTIVITY_HALF_LIFE.Hours())
+}
+
+func hourOfWeek(t time.Time) int {
+	t = t.UTC()
+	return int(t.Weekday())*24 + t.Hour()
+}
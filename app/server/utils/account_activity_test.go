@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/models"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+func TestAccountScheduleNextUpdate(t *testing.T) {
+	commonPlatforms.Register(&commonPlatforms.Definition{
+		PlatformID: "activity_test",
+		Metadata: commonTypes.PlatformMeta{
+			MinRefreshGap: 10 * time.Minute,
+			MaxRefreshGap: 24 * time.Hour,
+		},
+	})
+	t.Cleanup(func() {
+		commonPlatforms.Unregister("activity_test")
+	})
+
+	var account models.Account
+	account.Platform = "activity_test"
+
+	// Posts every day at 12:00 (UTC) for 4 weeks
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 28; day++ {
+		collectAt := start.AddDate(0, 0, day).Add(13 * time.Hour)
+		AccountScheduleNextUpdate(&account, []time.Time{collectAt.Add(-time.Hour)}, collectAt)
+	}
+
+	now := start.AddDate(0, 0, 28).Add(11*time.Hour + 30*time.Minute)
+	AccountScheduleNextUpdate(&account, nil, now)
+	if next := account.NextUpdate; next.Before(now.Add(30*time.Minute)) || next.After(now.Add(90*time.Minute)) {
+		t.Errorf("should poll around 12:00 when posts expected, got %s", next)
+	}
+
+	summary := AccountActivitySummary(&account, now)
+	if summary.Samples != 28 || len(summary.ActiveHours) == 0 || summary.ActiveHours[0]%24 != 12 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if summary.PostsPerWeek < 5 || summary.PostsPerWeek > 9 {
+		t.Errorf("should post about 7 times a week, got %f", summary.PostsPerWeek)
+	}
+
+	// Dormant for months
+	dormant := now.AddDate(0, 4, 0)
+	AccountScheduleNextUpdate(&account, nil, dormant)
+	if account.UpdateInterval != 24*time.Hour {
+		t.Errorf("dormant account should back off to max gap, got %s", account.UpdateInterval)
+	}
+
+	// Nothing learnt yet
+	var newAccount models.Account
+	newAccount.Platform = "activity_test"
+	newAccount.UpdateInterval = time.Hour
+	AccountScheduleNextUpdate(&newAccount, nil, now)
+	if newAccount.UpdateInterval != 2*time.Hour {
+		t.Errorf("should double interval without posts, got %s", newAccount.UpdateInterval)
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
 	"github.com/Crossbell-Box/OperatorSync/app/server/types"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"gorm.io/gorm"
 	"time"
 )
@@ -26,8 +26,11 @@ type BindAccountProps struct {
 }
 
 func AccountBind(props *BindAccountProps) (bool, string, error) {
-	if _, ok := commonConsts.SUPPORTED_PLATFORM[props.Platform]; !ok {
+	platform, ok := commonPlatforms.Meta(props.Platform)
+	if !ok {
 		return false, "Platform not supported", fmt.Errorf("platform not supported")
+	} else if !commonPlatforms.IsEnabled(props.Platform) {
+		return false, "Platform temporarily disabled", fmt.Errorf("platform disabled")
 	}
 
 	// Check character -> create if not exist
@@ -61,7 +64,7 @@ func AccountBind(props *BindAccountProps) (bool, string, error) {
 			return false, fmt.Sprintf("Account (%s@%s) has already been occupied by #%s, please unbind it first.", props.Username, props.Platform, account.CrossbellCharacterID), nil
 		}
 
-		if commonConsts.SUPPORTED_PLATFORM[props.Platform].Limit1Account {
+		if platform.Limit1Account {
 			// Check if already bind account on this platform
 			if err := global.DB.First(
 				&account,
@@ -120,7 +123,7 @@ func AccountBind(props *BindAccountProps) (bool, string, error) {
 					Platform:             props.Platform,
 					Username:             props.Username,
 					LastUpdated:          props.StartFrom,
-					UpdateInterval:       commonConsts.SUPPORTED_PLATFORM[props.Platform].MinRefreshGap,
+					UpdateInterval:       platform.MinRefreshGap,
 					NextUpdate:           time.Now(),
 					FeedsCount:           0,
 					NotesCount:           0,
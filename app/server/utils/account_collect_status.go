@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/server/config"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/models"
+	"github.com/Crossbell-Box/OperatorSync/app/server/types"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+// AccountCollectFailed : Record failure, delay next update, and suspend if account itself keeps failing.
+// Caller should save the account.
+func AccountCollectFailed(account *models.Account, workFailed *commonTypes.WorkFailed) {
+	account.RecentFailures = append(account.RecentFailures, types.CollectFailureRecord{
+		ErrorCode:   workFailed.ErrorCode,
+		ErrorReason: workFailed.ErrorReason,
+		ErrorAt:     workFailed.ErrorAt,
+		ItemID:      workFailed.ItemID,
+	})
+	if len(account.RecentFailures) > consts.COLLECT_FAILURE_RECORDS_KEEP {
+		account.RecentFailures = account.RecentFailures[len(account.RecentFailures)-consts.COLLECT_FAILURE_RECORDS_KEEP:]
+	}
+
+	if workFailed.ErrorAt.Before(account.LastUpdated) {
+		// Outdated, account has succeeded since then
+		return
+	}
+
+	account.ConsecutiveFailures++
+	if isAccountCollectFault(workFailed.ErrorCode) {
+		account.ConsecutiveFaults++
+	}
+
+	platform, _ := commonPlatforms.Meta(account.Platform)
+	retryAt := workFailed.ErrorAt.Add(collectFailureBackoff(platform.MinRefreshGap, account.ConsecutiveFailures))
+	if retryAt.After(account.NextUpdate) {
+		account.NextUpdate = retryAt
+	}
+
+	if config.Config.CollectSuspendThreshold > 0 &&
+		account.ConsecutiveFaults >= config.Config.CollectSuspendThreshold &&
+		!account.IsCollectSuspended {
+		account.IsCollectSuspended = true
+		account.CollectSuspendedAt = time.Now()
+		account.CollectSuspendMessage = fmt.Sprintf(
+			"Collect suspended after %d consecutive failures, last error (%d): %s",
+			account.ConsecutiveFaults, workFailed.ErrorCode, workFailed.ErrorReason,
+		)
+	}
+}
+
+// AccountCollectSucceeded : Reset failure counters, history is kept for reference.
+// Records the gap if feeds might be missed. Caller should save the account.
+func AccountCollectSucceeded(account *models.Account, gap *commonTypes.FeedGap, detectedAt time.Time) {
+	account.ConsecutiveFailures = 0
+	account.ConsecutiveFaults = 0
+
+	if gap != nil {
+		account.UnresolvedGaps = append(account.UnresolvedGaps, types.FeedGapRecord{
+			From:       gap.From,
+			To:         gap.To,
+			DetectedAt: detectedAt,
+		})
+		if len(account.UnresolvedGaps) > consts.COLLECT_GAP_RECORDS_KEEP {
+			account.UnresolvedGaps = account.UnresolvedGaps[len(account.UnresolvedGaps)-consts.COLLECT_GAP_RECORDS_KEEP:]
+		}
+	}
+}
+
+// AccountCollectResume : Lift suspension and give it another chance.
+// Caller should save the account.
+func AccountCollectResume(account *models.Account) {
+	account.ConsecutiveFailures = 0
+	account.ConsecutiveFaults = 0
+	account.IsCollectSuspended = false
+	account.CollectSuspendMessage = ""
+}
+
+// isAccountCollectFault : Whether the failure is related to account itself, rather than worker or upstream
+// (like upstream errors, RSSHub outages, rate limits, upload failures or panics), which only delays next update
+func isAccountCollectFault(errorCode uint) bool {
+	switch errorCode {
+	case commonConsts.ERROR_CODE_INVALID_FORMAT, commonConsts.ERROR_CODE_ACCOUNT_NOT_FOUND, commonConsts.ERROR_CODE_ACCOUNT_PRIVATE:
+		return true
+	default:
+		return false
+	}
+}
+
+// collectFailureBackoff : Doubles on each consecutive failure, limited by COLLECT_FAILURE_BACKOFF_LIMIT
+func collectFailureBackoff(base time.Duration, failures uint) time.Duration {
+	if base <= 0 {
+		base = time.Minute
+	}
+	backoff := base
+	for i := uint(1); i < failures; i++ {
+		backoff *= 2
+		if backoff >= consts.COLLECT_FAILURE_BACKOFF_LIMIT {
+			return consts.COLLECT_FAILURE_BACKOFF_LIMIT
+		}
+	}
+	if backoff > consts.COLLECT_FAILURE_BACKOFF_LIMIT {
+		return consts.COLLECT_FAILURE_BACKOFF_LIMIT
+	}
+	return backoff
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/server/config"
+	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/server/models"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+func TestCollectFailureBackoff(t *testing.T) {
+	if b := collectFailureBackoff(time.Minute, 1); b != time.Minute {
+		t.Errorf("first failure should wait base gap, got %v", b)
+	}
+	if b := collectFailureBackoff(time.Minute, 3); b != 4*time.Minute {
+		t.Errorf("third failure should wait 4x base gap, got %v", b)
+	}
+	if b := collectFailureBackoff(time.Minute, 100); b != consts.COLLECT_FAILURE_BACKOFF_LIMIT {
+		t.Errorf("backoff should be limited, got %v", b)
+	}
+}
+
+func TestAccountCollectFailed(t *testing.T) {
+	config.Config.CollectSuspendThreshold = 3
+
+	var account models.Account
+	account.Platform = "medium"
+	start := time.Now()
+	for i := 0; i < consts.COLLECT_FAILURE_RECORDS_KEEP+2; i++ {
+		AccountCollectFailed(&account, &commonTypes.WorkFailed{
+			ErrorAt:     start.Add(time.Duration(i) * time.Second),
+			ErrorCode:   commonConsts.ERROR_CODE_ACCOUNT_NOT_FOUND,
+			ErrorReason: "failed",
+		})
+		if i == 1 && account.IsCollectSuspended {
+			t.Error("suspended before threshold")
+		}
+	}
+
+	if len(account.RecentFailures) != consts.COLLECT_FAILURE_RECORDS_KEEP {
+		t.Errorf("should keep %d records, got %d", consts.COLLECT_FAILURE_RECORDS_KEEP, len(account.RecentFailures))
+	}
+	if !account.IsCollectSuspended || account.CollectSuspendMessage == "" {
+		t.Error("should be suspended with message")
+	}
+	if !account.NextUpdate.After(start) {
+		t.Error("next update should be delayed")
+	}
+
+	AccountCollectResume(&account)
+	if account.IsCollectSuspended || account.ConsecutiveFailures != 0 || account.ConsecutiveFaults != 0 {
+		t.Error("should be resumed")
+	}
+
+	for _, errorCode := range []uint{
+		commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED,
+		commonConsts.ERROR_CODE_CIRCUIT_OPEN,
+		commonConsts.ERROR_CODE_RATE_LIMITED,
+		commonConsts.ERROR_CODE_FAILED_TO_UPLOAD,
+		commonConsts.ERROR_CODE_WORK_PANICKED,
+	} {
+		AccountCollectFailed(&account, &commonTypes.WorkFailed{
+			ErrorAt:   start,
+			ErrorCode: errorCode,
+		})
+		if account.ConsecutiveFaults != 0 || account.IsCollectSuspended {
+			t.Errorf("failure %d not of account should not count towards suspension", errorCode)
+		}
+	}
+	if account.ConsecutiveFailures != 5 {
+		t.Errorf("all failures should be counted for backoff, got %d", account.ConsecutiveFailures)
+	}
+}
+
+func TestAccountCollectFailedUpstream(t *testing.T) {
+	config.Config.CollectSuspendThreshold = 3
+
+	var account models.Account
+	account.Platform = "medium"
+	start := time.Now()
+
+	var lastDelay time.Duration
+	for i := 0; i < 4; i++ {
+		errorAt := start.Add(time.Duration(i) * time.Second)
+		AccountCollectFailed(&account, &commonTypes.WorkFailed{
+			ErrorAt:   errorAt,
+			ErrorCode: commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED,
+		})
+		delay := account.NextUpdate.Sub(errorAt)
+		if delay <= lastDelay {
+			t.Errorf("backoff should grow on upstream error, got %v after %v", delay, lastDelay)
+		}
+		lastDelay = delay
+	}
+
+	if account.IsCollectSuspended {
+		t.Error("should not be suspended for upstream errors")
+	}
+}
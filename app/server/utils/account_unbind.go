@@ -7,8 +7,8 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/server/consts"
 	"github.com/Crossbell-Box/OperatorSync/app/server/global"
 	"github.com/Crossbell-Box/OperatorSync/app/server/models"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"gorm.io/gorm"
 )
@@ -21,7 +21,7 @@ type UnbindAccountProps struct {
 }
 
 func AccountUnbind(props *UnbindAccountProps) (bool, string, error) {
-	if _, ok := commonConsts.SUPPORTED_PLATFORM[props.Platform]; !ok {
+	if !commonPlatforms.IsSupported(props.Platform) {
 		return false, "Platform not supported", fmt.Errorf("platform not supported")
 	}
 
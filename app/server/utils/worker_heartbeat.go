@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/app/server/global"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+// LiveWorkers : Heartbeats of workers alive, stale ones are removed
+func LiveWorkers(ctx context.Context) (map[string]commonTypes.WorkerHeartbeat, error) {
+	rawHeartbeats, err := commonGlobal.Redis.HGetAll(ctx, commonConsts.REDIS_WorkerHeartbeatKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	workers, stale := parseHeartbeats(rawHeartbeats, time.Now())
+	if len(stale) > 0 {
+		if err := commonGlobal.Redis.HDel(ctx, commonConsts.REDIS_WorkerHeartbeatKey, stale...).Err(); err != nil {
+			global.Logger.Errorf("Failed to remove stale worker heartbeats with error: %s", err.Error())
+		}
+	}
+
+	return workers, nil
+}
+
+// ServedPlatforms : Count of live workers serving each platform
+func ServedPlatforms(workers map[string]commonTypes.WorkerHeartbeat) map[string]int {
+	served := make(map[string]int)
+	for _, heartbeat := range workers {
+		for _, platformID := range heartbeat.Platforms {
+			served[platformID]++
+		}
+	}
+	return served
+}
+
+// parseHeartbeats : Live heartbeats at now, and IDs of invalid or gone workers
+func parseHeartbeats(rawHeartbeats map[string]string, now time.Time) (map[string]commonTypes.WorkerHeartbeat, []string) {
+	workers := make(map[string]commonTypes.WorkerHeartbeat)
+	var stale []string
+
+	for workerID, raw := range rawHeartbeats {
+		var heartbeat commonTypes.WorkerHeartbeat
+		if err := json.Unmarshal([]byte(raw), &heartbeat); err != nil || now.Sub(heartbeat.BeatAt) > commonConsts.REDIS_WorkerHeartbeatExpires {
+			stale = append(stale, workerID)
+			continue
+		}
+		workers[workerID] = heartbeat
+	}
+
+	return workers, stale
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"encoding/json"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+func TestParseHeartbeats(t *testing.T) {
+	now := time.Now()
+
+	heartbeat := func(beatAt time.Time, platforms ...string) string {
+		raw, _ := json.Marshal(&commonTypes.WorkerHeartbeat{BeatAt: beatAt, Platforms: platforms})
+		return string(raw)
+	}
+
+	workers, stale := parseHeartbeats(map[string]string{
+		"full":    heartbeat(now.Add(-time.Second), "pixiv", "medium"),
+		"partial": heartbeat(now, "medium"),
+		"gone":    heartbeat(now.Add(-2*commonConsts.REDIS_WorkerHeartbeatExpires), "tiktok"),
+		"invalid": "{",
+	}, now)
+
+	if len(workers) != 2 || len(stale) != 2 {
+		t.Fatalf("Expected 2 live and 2 stale workers, got %v and %v", workers, stale)
+	}
+
+	served := ServedPlatforms(workers)
+	if served["pixiv"] != 1 || served["medium"] != 2 || served["tiktok"] != 0 {
+		t.Errorf("Unexpected served platforms: %v", served)
+	}
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonMiddleware "github.com/Crossbell-Box/OperatorSync/common/middleware"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Start : Serve admin endpoints of this worker in background, skipped if no token set
+func Start() {
+	if config.Config.WorkerAdminToken == "" {
+		global.Logger.Debug("Worker admin token not set, skip admin endpoints")
+		return
+	}
+
+	if !config.Config.DevelopmentMode {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	engine := gin.Default()
+	rg := engine.Group("/admin", commonMiddleware.AdminAuth(config.Config.WorkerAdminToken))
+	rg.GET("/concurrency", ListConcurrency)
+	rg.PUT("/concurrency/platforms/:platform", SetPlatformConcurrency)
+	rg.DELETE("/concurrency/platforms/:platform", ResetPlatformConcurrency)
+	rg.PUT("/concurrency/classes/:class", SetClassConcurrency)
+
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%s", config.Config.WorkerAdminPort),
+		Handler: engine,
+	}
+
+	go func() {
+		global.Logger.Infof("Listening and serving admin endpoints on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			global.Logger.Fatal("Failed to start admin endpoints: ", err.Error())
+		}
+	}()
+
+	shutdown.OnClose(func(ctx context.Context) {
+		if err := srv.Shutdown(ctx); err != nil {
+			global.Logger.Errorf("Failed to shutdown admin endpoints with error: %s", err.Error())
+		}
+	})
+}
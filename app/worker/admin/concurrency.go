@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/concurrency"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type platformConcurrencyRequest struct {
+	Limit  int `json:"limit"`  // 0 to be limited by class only
+	Weight int `json:"weight"` // Class slots taken by each work, default 1
+}
+
+type classConcurrencyRequest struct {
+	Limit int `json:"limit"`
+}
+
+// ListConcurrency : Limits and gauges of each platform on this worker
+func ListConcurrency(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Concurrency of platforms",
+		"result":  concurrency.Stats(),
+	})
+}
+
+// SetPlatformConcurrency : Change limit and weight of a platform on this worker, until restarted
+func SetPlatformConcurrency(ctx *gin.Context) {
+	reqPlatform := ctx.Param("platform")
+
+	req := platformConcurrencyRequest{Weight: 1}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to parse request: " + err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	if err := concurrency.SetPlatform(reqPlatform, req.Limit, req.Weight); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	global.Logger.Infof("Concurrency of platform %s updated: limit %d, weight %d", reqPlatform, req.Limit, req.Weight)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Platform concurrency updated",
+		"result":  concurrency.Stats()[reqPlatform],
+	})
+}
+
+// ResetPlatformConcurrency : Limit platform by class only, and weigh 1
+func ResetPlatformConcurrency(ctx *gin.Context) {
+	reqPlatform := ctx.Param("platform")
+
+	if err := concurrency.SetPlatform(reqPlatform, 0, 1); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	global.Logger.Infof("Concurrency of platform %s reset", reqPlatform)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Platform concurrency reset",
+		"result":  concurrency.Stats()[reqPlatform],
+	})
+}
+
+// SetClassConcurrency : Change limit shared by platforms of a class on this worker, until restarted
+func SetClassConcurrency(ctx *gin.Context) {
+	reqClass := commonTypes.ConcurrencyClass(ctx.Param("class"))
+
+	var req classConcurrencyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": "Failed to parse request: " + err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	if err := concurrency.SetClass(reqClass, req.Limit); err != nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"ok":      false,
+			"message": err.Error(),
+			"result":  nil,
+		})
+		return
+	}
+
+	global.Logger.Infof("Concurrency of class %s updated: limit %d", reqClass, req.Limit)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"ok":      true,
+		"message": "Class concurrency updated",
+		"result":  nil,
+	})
+}
@@ -0,0 +1,229 @@
+package concurrency
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"sync"
+	"time"
+)
+
+// Each feed collect work takes a slot of its platform, and as many slots as its platform weighs of its class
+// (shared with other platforms of the same class). Platforms without limits of their own are limited by class only.
+
+type platformSlots struct {
+	class commonTypes.ConcurrencyClass
+	own   *semaphore
+
+	lock    sync.Mutex
+	weight  int
+	waiting int
+	waitMs  float64 // Moving average
+}
+
+var (
+	lock      sync.RWMutex
+	classes   = make(map[commonTypes.ConcurrencyClass]*semaphore)
+	platforms = make(map[string]*platformSlots)
+
+	hooksLock sync.Mutex
+	hooks     []func()
+)
+
+// Init : Prepare class limits, and limits & weights of platforms (others limited by class only and weigh 1)
+func Init(classLimits map[commonTypes.ConcurrencyClass]int, platformLimits map[string]int, platformWeights map[string]int) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	classes = make(map[commonTypes.ConcurrencyClass]*semaphore)
+	for class, limit := range classLimits {
+		classes[class] = newSemaphore(limit)
+	}
+
+	platforms = make(map[string]*platformSlots)
+	for _, platformID := range commonPlatforms.IDs() {
+		p := newPlatformSlots(platformID)
+		p.own.limit = platformLimits[platformID]
+		if weight, ok := platformWeights[platformID]; ok {
+			p.weight = weight
+		}
+		platforms[platformID] = p
+	}
+}
+
+// Acquire : Wait for slots of platform, call release when work finished
+func Acquire(platformID string) (release func()) {
+	p, c := slotsOf(platformID)
+
+	p.lock.Lock()
+	p.waiting++
+	weight := p.weight
+	p.lock.Unlock()
+
+	startAt := time.Now()
+	p.own.acquire(1)
+	c.acquire(weight)
+	waited := time.Since(startAt)
+
+	p.lock.Lock()
+	p.waiting--
+	p.waitMs = p.waitMs*(1-consts.CONCURRENCY_WAIT_DECAY) + float64(waited.Milliseconds())*consts.CONCURRENCY_WAIT_DECAY
+	p.lock.Unlock()
+
+	return func() {
+		c.release(weight)
+		p.own.release(1)
+	}
+}
+
+// SetPlatform : Change limit (0 to be limited by class only) and weight of platform, works in progress keep their slots
+func SetPlatform(platformID string, limit int, weight int) error {
+	if !commonPlatforms.IsSupported(platformID) {
+		return fmt.Errorf("platform %s not supported", platformID)
+	} else if limit < 0 {
+		return fmt.Errorf("limit should not be negative")
+	} else if weight <= 0 {
+		return fmt.Errorf("weight should be positive")
+	}
+
+	p, _ := slotsOf(platformID)
+	p.own.setLimit(limit)
+
+	p.lock.Lock()
+	p.weight = weight
+	p.lock.Unlock()
+
+	changed()
+
+	return nil
+}
+
+// SetClass : Change limit of class
+func SetClass(class commonTypes.ConcurrencyClass, limit int) error {
+	if limit <= 0 {
+		return fmt.Errorf("limit should be positive")
+	}
+
+	lock.RLock()
+	c, ok := classes[class]
+	lock.RUnlock()
+	if !ok {
+		return fmt.Errorf("concurrency class %s not found", class)
+	}
+
+	c.setLimit(limit)
+
+	changed()
+
+	return nil
+}
+
+// OnChange : Register hook called after limits changed at runtime, like to adjust prefetch
+func OnChange(hook func()) {
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+
+	hooks = append(hooks, hook)
+}
+
+func changed() {
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// Prefetch : Works of platform able to start at once, so no more should be taken from queue.
+// Limited by own slots, or by share of class slots, or fallback (also the upper bound) if unlimited.
+func Prefetch(platformID string, fallback int) int {
+	p, c := slotsOf(platformID)
+
+	prefetch, _ := p.own.snapshot()
+	if prefetch <= 0 {
+		classLimit, _ := c.snapshot()
+		if classLimit > 0 {
+			p.lock.Lock()
+			prefetch = classLimit / p.weight
+			p.lock.Unlock()
+			if prefetch < 1 {
+				// Heavier than limit runs alone
+				prefetch = 1
+			}
+		}
+	}
+
+	if prefetch <= 0 || (fallback > 0 && prefetch > fallback) {
+		prefetch = fallback
+	}
+	return prefetch
+}
+
+// Stats : Limits and gauges of each platform
+func Stats() map[string]commonTypes.ConcurrencyStats {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	stats := make(map[string]commonTypes.ConcurrencyStats, len(platforms))
+	for platformID, p := range platforms {
+		s := commonTypes.ConcurrencyStats{Class: p.class}
+		s.Limit, s.Active = p.own.snapshot()
+		if c, ok := classes[p.class]; ok {
+			s.ClassLimit, s.ClassUsed = c.snapshot()
+		}
+
+		p.lock.Lock()
+		s.Weight = p.weight
+		s.Waiting = p.waiting
+		s.WaitMs = p.waitMs
+		p.lock.Unlock()
+
+		stats[platformID] = s
+	}
+
+	return stats
+}
+
+// slotsOf : Slots of platform and its class, created if not initialized
+func slotsOf(platformID string) (*platformSlots, *semaphore) {
+	lock.RLock()
+	p, ok := platforms[platformID]
+	var c *semaphore
+	if ok {
+		c, ok = classes[p.class]
+	}
+	lock.RUnlock()
+	if ok {
+		return p, c
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if p, ok = platforms[platformID]; !ok {
+		p = newPlatformSlots(platformID)
+		platforms[platformID] = p
+	}
+	if c, ok = classes[p.class]; !ok {
+		// Unknown class, unlimited
+		c = newSemaphore(0)
+		classes[p.class] = c
+	}
+
+	return p, c
+}
+
+func newPlatformSlots(platformID string) *platformSlots {
+	class := commonTypes.ConcurrencyClassDirect
+	if platform, ok := commonPlatforms.Get(platformID); ok {
+		class = platform.ConcurrencyClass()
+	}
+
+	return &platformSlots{
+		class:  class,
+		own:    newSemaphore(0),
+		weight: 1,
+	}
+}
@@ -0,0 +1,95 @@
+package concurrency
+
+import (
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+func TestSemaphore(t *testing.T) {
+	s := newSemaphore(3)
+	s.acquire(2)
+
+	acquired := make(chan struct{})
+	go func() {
+		s.acquire(2)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Acquired beyond limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Raised limit takes effect on waiters
+	s.setLimit(4)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Not acquired after limit raised")
+	}
+
+	if limit, used := s.snapshot(); limit != 4 || used != 4 {
+		t.Errorf("Expected 4 of 4 used, got %d of %d", used, limit)
+	}
+
+	// Heavier than limit runs alone
+	heavy := newSemaphore(1)
+	heavy.acquire(5)
+	heavy.release(5)
+}
+
+func TestAcquire(t *testing.T) {
+	if !commonPlatforms.IsSupported("concurrency_test") {
+		commonPlatforms.Register(&commonPlatforms.Definition{
+			PlatformID:  "concurrency_test",
+			Concurrency: commonTypes.ConcurrencyClassDirect,
+		})
+	}
+
+	Init(map[commonTypes.ConcurrencyClass]int{
+		commonTypes.ConcurrencyClassDirect: 10,
+	}, map[string]int{"concurrency_test": 1}, map[string]int{"concurrency_test": 4})
+
+	if prefetch := Prefetch("concurrency_test", 100); prefetch != 1 {
+		t.Errorf("Expected prefetch limited by own slots, got %d", prefetch)
+	}
+
+	release := Acquire("concurrency_test")
+
+	stats := Stats()["concurrency_test"]
+	if stats.Limit != 1 || stats.Active != 1 || stats.ClassUsed != 4 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	waiting := make(chan struct{})
+	go func() {
+		Acquire("concurrency_test")()
+		close(waiting)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if stats = Stats()["concurrency_test"]; stats.Waiting != 1 {
+		t.Errorf("Expected 1 waiting, got %+v", stats)
+	}
+
+	release()
+	<-waiting
+
+	if err := SetPlatform("concurrency_test", 0, 0); err == nil {
+		t.Error("Expected error of invalid weight")
+	}
+	if err := SetPlatform("concurrency_test", 0, 1); err != nil {
+		t.Error(err)
+	}
+	if stats = Stats()["concurrency_test"]; stats.Limit != 0 || stats.Weight != 1 || stats.Active != 0 || stats.ClassUsed != 0 {
+		t.Errorf("Unexpected stats after reset: %+v", stats)
+	}
+	if prefetch := Prefetch("concurrency_test", 100); prefetch != 10 {
+		t.Errorf("Expected prefetch limited by class, got %d", prefetch)
+	}
+	if prefetch := Prefetch("concurrency_test", 5); prefetch != 5 {
+		t.Errorf("Expected prefetch limited by fallback, got %d", prefetch)
+	}
+}
@@ -0,0 +1,57 @@
+package concurrency
+
+import "sync"
+
+// semaphore : Weighted slots with limit adjustable at runtime, unlimited if limit is 0
+type semaphore struct {
+	lock  sync.Mutex
+	cond  *sync.Cond
+	limit int
+	used  int
+}
+
+func newSemaphore(limit int) *semaphore {
+	s := &semaphore{limit: limit}
+	s.cond = sync.NewCond(&s.lock)
+	return s
+}
+
+func (s *semaphore) acquire(weight int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for !s.fits(weight) {
+		s.cond.Wait()
+	}
+	s.used += weight
+}
+
+func (s *semaphore) release(weight int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.used -= weight
+	// Waiters of different weights might fit now
+	s.cond.Broadcast()
+}
+
+func (s *semaphore) setLimit(limit int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.limit = limit
+	s.cond.Broadcast()
+}
+
+// snapshot : Current limit and slots used
+func (s *semaphore) snapshot() (int, int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.limit, s.used
+}
+
+// fits : Heavier than limit runs alone, or it would never start
+func (s *semaphore) fits(weight int) bool {
+	return s.limit <= 0 || s.used+weight <= s.limit || s.used == 0
+}
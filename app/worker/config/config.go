@@ -3,20 +3,48 @@ package config
 import (
 	commonConfig "github.com/Crossbell-Box/OperatorSync/common/config"
 	"net/url"
+	"time"
 )
 
 type workerConfig struct {
-	RSSHubEndpointStateful  string
-	RSSHubEndpointStateless string
+	WorkerID  string   // Identify this worker in metrics, default hostname
+	Platforms []string // Platforms served by this worker, default all built-in platforms
+
+	RSSHubEndpointsStateful  []string
+	RSSHubEndpointsStateless []string
+
+	BlueskyPDSEndpoint string   // AT Protocol XRPC server (PDS / AppView)
+	NostrRelays        []string // Nostr relay WebSocket URLs
 
 	ProxyURL *url.URL
 
+	// HTTP client
+	HttpTimeout     time.Duration // Per request (each attempt)
+	HttpMaxBodySize int64         // In bytes
+	HttpUserAgent   string
+	HttpRetries     int // Extra attempts for idempotent requests
+
+	// Media downloaded and uploaded to IPFS
+	MediaTimeout     time.Duration // Per request (each attempt)
+	MediaMaxBodySize int64         // In bytes
+
+	// Upstream protection
+	HostRateLimit           float64 // Requests per second for each host, 0 for unlimited
+	HostRateBurst           int
+	CircuitBreakerThreshold int // Consecutive failures before circuit opens
+
 	IPFSEndpoint string
 
 	// Concurrency control
 	ConcurrencyStateful  int
 	ConcurrencyStateless int
 	ConcurrencyDirect    int
+	ConcurrencyPlatforms map[string]int // Own limits of platforms, others limited by class only
+	ConcurrencyWeights   map[string]int // Class slots taken by each work of platforms, default 1
+
+	// Admin endpoints
+	WorkerAdminPort  string
+	WorkerAdminToken string // Bearer token, admin endpoints disabled if empty
 
 	// Crossbell chain related
 	CrossbellChainID         int64
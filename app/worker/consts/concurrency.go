@@ -0,0 +1,5 @@
+package consts
+
+const (
+	CONCURRENCY_WAIT_DECAY = 0.2 // Weight of latest wait in moving average
+)
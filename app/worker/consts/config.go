@@ -7,6 +7,32 @@ const (
 	CONFIG_DEFAULT_CONCURRENCY_CONTROL_STATELESS = 50
 	CONFIG_DEFAULT_CONCURRENCY_CONTROL_DIRECT    = 100
 
+	CONFIG_DEFAULT_WORKER_ADMIN_PORT = "22916"
+
+	CONFIG_DEFAULT_BLUESKY_PDS_ENDPOINT = "https://public.api.bsky.app" // Public AppView, no auth required
+	CONFIG_DEFAULT_NOSTR_RELAYS         = "wss://relay.damus.io,wss://nos.lol,wss://relay.nostr.band"
+
+	CONFIG_DEFAULT_HTTP_TIMEOUT       = 30 * time.Second // HTTP client
+	CONFIG_DEFAULT_HTTP_MAX_BODY_SIZE = 50 << 20         // 50 MiB, for feeds and API responses
+	CONFIG_DEFAULT_HTTP_USER_AGENT    = "OperatorSync/1.0 (+https://github.com/Crossbell-Box/OperatorSync)"
+	CONFIG_DEFAULT_HTTP_RETRIES       = 2
+
+	CONFIG_DEFAULT_MEDIA_TIMEOUT       = 5 * time.Minute // Media uploaded to IPFS, like podcast audios
+	CONFIG_DEFAULT_MEDIA_MAX_BODY_SIZE = 500 << 20       // 500 MiB
+
+	CONFIG_DEFAULT_HOST_RATE_LIMIT           = 5.0 // Requests per second for each upstream host
+	CONFIG_DEFAULT_HOST_RATE_BURST           = 10
+	CONFIG_DEFAULT_CIRCUIT_BREAKER_THRESHOLD = 5 // Consecutive failures before circuit opens
+
+	HTTP_RETRY_BASE_DELAY        = 500 * time.Millisecond
+	HTTP_RETRY_MAX_DELAY         = 10 * time.Second
+	HTTP_ERROR_BODY_PREVIEW_SIZE = 256 // Bytes of error response body kept in error message
+	HTTP_RETRY_AFTER_MAX         = 1 * time.Hour
+
+	HOST_RATE_LIMIT_MAX_WAIT      = 30 * time.Second // Fail instead of waiting longer
+	CIRCUIT_BREAKER_COOLDOWN_BASE = 30 * time.Second
+	CIRCUIT_BREAKER_COOLDOWN_MAX  = 30 * time.Minute
+
 	CONFIG_DEFAULT_CROSSBELL_CHAIN_ID         = 3737 // Crossbell chain related
 	CONFIG_DEFAULT_CROSSBELL_JSON_RPC         = "https://rpc.crossbell.io"
 	CONFIG_DEFAULT_CROSSBELL_INDEXER          = "https://indexer.crossbell.io"
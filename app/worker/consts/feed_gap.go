@@ -0,0 +1,7 @@
+package consts
+
+const (
+	DEEP_FETCH_MAX_PAGES      = 5   // Extra pages fetched at most when a gap detected
+	RSSHUB_DEEP_FETCH_LIMIT   = 200 // Items requested with ?limit= from RSSHub
+	MASTODON_DEEP_FETCH_LIMIT = 40  // Max statuses per page of Mastodon API
+)
@@ -0,0 +1,8 @@
+package consts
+
+import "time"
+
+const (
+	METRICS_REPORT_INTERVAL = 30 * time.Second
+	HEARTBEAT_INTERVAL      = 10 * time.Second // Should be well within REDIS_WorkerHeartbeatExpires
+)
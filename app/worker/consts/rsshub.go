@@ -0,0 +1,10 @@
+package consts
+
+import "time"
+
+const (
+	RSSHUB_HEALTH_DECAY            = 0.2 // Weight of latest request in moving averages
+	RSSHUB_COOLDOWN_AFTER_FAILURES = 3   // Consecutive failures before taken out of rotation
+	RSSHUB_COOLDOWN_BASE           = 30 * time.Second
+	RSSHUB_COOLDOWN_MAX            = 10 * time.Minute
+)
@@ -0,0 +1,41 @@
+package httpclient
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"net/http"
+	"sync"
+)
+
+var (
+	clientsLock sync.Mutex
+	clients     = make(map[string]*http.Client) // proxy URL, empty if not proxied : client
+)
+
+// New : Shared client (so connections are reused), with proxy if required and configured
+func New(withProxy bool) *http.Client {
+	clientsLock.Lock()
+	defer clientsLock.Unlock()
+
+	proxy := ""
+	if withProxy && config.Config.ProxyURL != nil {
+		proxy = config.Config.ProxyURL.String()
+	}
+
+	if client, ok := clients[proxy]; ok {
+		return client
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.Proxy = nil
+	tr.DialContext = DialContext
+	if proxy != "" {
+		tr.Proxy = ProxyChecked(config.Config.ProxyURL)
+	}
+
+	client := &http.Client{
+		Transport: tr,
+	}
+	clients[proxy] = client
+
+	return client
+}
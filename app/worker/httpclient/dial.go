@@ -0,0 +1,131 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var ErrPrivateAddress = errors.New("private address not allowed")
+
+var (
+	trustedHostsLock sync.RWMutex
+	trustedHosts     = make(map[string]bool) // host:port : trusted
+
+	// Untrusted connections are checked after DNS resolution, so rebinding and redirects are covered
+	untrustedDialer = &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   checkDialAddress,
+	}
+	trustedDialer = &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+)
+
+// TrustHost : Allow connecting to our own services (like RSSHub, IPFS relay or proxy) in private networks
+func TrustHost(rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return
+	}
+
+	trustedHostsLock.Lock()
+	defer trustedHostsLock.Unlock()
+
+	trustedHosts[hostPort(u)] = true
+}
+
+// hostPort : Address to dial for URL, with default port of scheme
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https", "wss":
+			port = "443"
+		case "socks5", "socks5h":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+func isTrusted(addr string) bool {
+	trustedHostsLock.RLock()
+	defer trustedHostsLock.RUnlock()
+
+	return trustedHosts[addr]
+}
+
+// DialContext : Dial upstream, refuse loopback, private, link-local and unspecified addresses unless trusted
+func DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	if isTrusted(addr) {
+		return trustedDialer.DialContext(ctx, network, addr)
+	}
+	return untrustedDialer.DialContext(ctx, network, addr)
+}
+
+// ProxyChecked : Proxy all requests to proxyURL. Only the proxy is dialed, so target is resolved and checked here instead
+func ProxyChecked(proxyURL *url.URL) func(req *http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if err := checkTarget(req.Context(), req.URL); err != nil {
+			return nil, err
+		}
+		return proxyURL, nil
+	}
+}
+
+// checkTarget : Refuse target URL if any address of it is private, unless trusted
+func checkTarget(ctx context.Context, u *url.URL) error {
+	if isTrusted(hostPort(u)) {
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if isPrivateIP(addr.IP) {
+			return fmt.Errorf("%w: %s", ErrPrivateAddress, addr.IP.String())
+		}
+	}
+
+	return nil
+}
+
+// checkDialAddress : Called with resolved IP right before connecting
+func checkDialAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: unresolved %s", ErrPrivateAddress, host)
+	}
+	if isPrivateIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, ip.String())
+	}
+
+	return nil
+}
+
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
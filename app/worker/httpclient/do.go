@@ -0,0 +1,222 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// Response : Response with body already read, so connection can be released
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// StatusError : Response received, but not 2xx
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+	Body       []byte        // Beginning part, for debug
+	RetryAfter time.Duration // Told by upstream, with 429 or 503
+}
+
+func (e *StatusError) Error() string {
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("request %s failed with status %s: %s", e.URL, e.Status, e.Body)
+	}
+	return fmt.Sprintf("request %s failed with status %s", e.URL, e.Status)
+}
+
+// IsStatus : Check if error is a StatusError with specified status code
+func IsStatus(err error, statusCode int) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == statusCode
+}
+
+// Limits : Of each attempt, zero for unlimited
+type Limits struct {
+	Timeout     time.Duration
+	MaxBodySize int64 // In bytes
+}
+
+// DefaultLimits : For feeds and API responses
+func DefaultLimits() Limits {
+	return Limits{
+		Timeout:     config.Config.HttpTimeout,
+		MaxBodySize: config.Config.HttpMaxBodySize,
+	}
+}
+
+// MediaLimits : For media (like images, audios) downloaded and uploaded to IPFS, which are much larger and slower
+func MediaLimits() Limits {
+	return Limits{
+		Timeout:     config.Config.MediaTimeout,
+		MaxBodySize: config.Config.MediaMaxBodySize,
+	}
+}
+
+// Do : Execute request with shared client
+func Do(req *http.Request, withProxy bool) (*Response, error) {
+	return DoWithClient(New(withProxy), req)
+}
+
+// DoMedia : Execute request with shared client and media limits
+func DoMedia(req *http.Request, withProxy bool) (*Response, error) {
+	return DoWithLimits(New(withProxy), req, MediaLimits())
+}
+
+// DoWithClient : Execute request with default limits
+func DoWithClient(client *http.Client, req *http.Request) (*Response, error) {
+	return DoWithLimits(client, req, DefaultLimits())
+}
+
+// DoWithLimits : Execute request with deadline, User-Agent, body size limit and status check.
+// Idempotent requests (GET / HEAD) are retried with jitter if upstream is unavailable (see IsUnavailable).
+// Requests are rate limited per host, and fail fast if circuit of the host is open.
+func DoWithLimits(client *http.Client, req *http.Request, limits Limits) (*Response, error) {
+	if req.Header.Get("User-Agent") == "" && config.Config.HttpUserAgent != "" {
+		req.Header.Set("User-Agent", config.Config.HttpUserAgent)
+	}
+
+	attempts := 1
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		attempts += config.Config.HttpRetries
+	}
+
+	guard := guardFor(req.URL.Host)
+
+	var (
+		res *Response
+		err error
+	)
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := retryDelay(attempt)
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+				if statusErr.RetryAfter > consts.HTTP_RETRY_MAX_DELAY {
+					// Too long to wait
+					break
+				}
+				delay = statusErr.RetryAfter
+			}
+			global.Logger.Debugf("Retry request %s in %s (attempt %d) because of: %s", req.URL.String(), delay, attempt+1, err.Error())
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-time.After(delay):
+			}
+		}
+
+		if guard != nil {
+			if err := guard.acquire(req.Context(), req.URL.Host); err != nil {
+				return nil, err
+			}
+		}
+
+		res, err = do(client, req, limits)
+
+		if guard != nil {
+			guard.record(err)
+		}
+
+		if !IsUnavailable(err) {
+			break
+		}
+	}
+
+	return res, err
+}
+
+func do(client *http.Client, req *http.Request, limits Limits) (*Response, error) {
+	ctx := req.Context()
+	if limits.Timeout > 0 {
+		// Parent deadline is kept if earlier
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+
+	rawRes, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rawRes.Body.Close()
+
+	var reader io.Reader = rawRes.Body
+	if limits.MaxBodySize > 0 {
+		reader = io.LimitReader(rawRes.Body, limits.MaxBodySize+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if limits.MaxBodySize > 0 && int64(len(body)) > limits.MaxBodySize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrBodyTooLarge, req.URL.String(), limits.MaxBodySize)
+	}
+
+	res := &Response{
+		StatusCode: rawRes.StatusCode,
+		Header:     rawRes.Header,
+		Body:       body,
+	}
+
+	if rawRes.StatusCode < 200 || rawRes.StatusCode >= 300 {
+		statusErr := &StatusError{
+			URL:        req.URL.String(),
+			StatusCode: rawRes.StatusCode,
+			Status:     rawRes.Status,
+			Body:       body,
+		}
+		if rawRes.StatusCode == http.StatusTooManyRequests || rawRes.StatusCode == http.StatusServiceUnavailable {
+			statusErr.RetryAfter = parseRetryAfter(rawRes.Header.Get("Retry-After"))
+		}
+		if len(statusErr.Body) > consts.HTTP_ERROR_BODY_PREVIEW_SIZE {
+			statusErr.Body = statusErr.Body[:consts.HTTP_ERROR_BODY_PREVIEW_SIZE]
+		}
+		return res, statusErr
+	}
+
+	return res, nil
+}
+
+// IsUnavailable : Upstream itself is unavailable for now (network errors, timeout, 429, 502, 503 or 504),
+// rather than failed on this request (like 500 of a broken route), which would be the same if tried again
+func IsUnavailable(err error) bool {
+	if err == nil || errors.Is(err, ErrBodyTooLarge) || errors.Is(err, ErrPrivateAddress) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// Network errors, timeout
+	return true
+}
+
+// retryDelay : Exponential backoff with full jitter
+func retryDelay(attempt int) time.Duration {
+	backoff := consts.HTTP_RETRY_BASE_DELAY << (attempt - 1)
+	if backoff > consts.HTTP_RETRY_MAX_DELAY {
+		backoff = consts.HTTP_RETRY_MAX_DELAY
+	}
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	global.Logger = logger.Sugar()
+
+	config.Config.HttpTimeout = 5 * time.Second
+	config.Config.HttpMaxBodySize = 16
+	config.Config.HttpUserAgent = "OperatorSync-Test"
+	config.Config.HttpRetries = 2
+	config.Config.MediaTimeout = 5 * time.Second
+	config.Config.MediaMaxBodySize = 32
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.UserAgent() != "OperatorSync-Test" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/flaky":
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 17)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	TrustHost(server.URL)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/flaky", nil)
+	if res, err := Do(req, false); err != nil || string(res.Body) != "ok" || attempts != 3 {
+		t.Fatalf("should succeed after retries, got %v after %d attempts", err, attempts)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/large", nil)
+	if _, err := Do(req, false); !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("should be too large, got %v", err)
+	}
+
+	// Media has its own limit
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/large", nil)
+	if res, err := DoMedia(req, false); err != nil || len(res.Body) != 17 {
+		t.Fatalf("should be within media limit, got %v", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL+"/missing", nil)
+	if _, err := Do(req, false); !IsStatus(err, http.StatusNotFound) || ErrorCode(err) != commonConsts.ERROR_CODE_ACCOUNT_NOT_FOUND {
+		t.Fatalf("should be not found, got %v", err)
+	}
+
+	// Not idempotent, never retried
+	attempts = 0
+	req, _ = http.NewRequest(http.MethodPost, server.URL+"/flaky", nil)
+	if _, err := Do(req, false); !IsStatus(err, http.StatusBadGateway) || attempts != 1 {
+		t.Fatalf("should fail without retry, got %v after %d attempts", err, attempts)
+	}
+}
@@ -0,0 +1,31 @@
+package httpclient
+
+import (
+	"errors"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"net/http"
+)
+
+// ErrorCode : Error code of failed request, for WorkFailed
+func ErrorCode(err error) uint {
+	if errors.Is(err, ErrCircuitOpen) {
+		return commonConsts.ERROR_CODE_CIRCUIT_OPEN
+	}
+	if errors.Is(err, ErrRateLimited) {
+		return commonConsts.ERROR_CODE_RATE_LIMITED
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusNotFound, http.StatusGone:
+			return commonConsts.ERROR_CODE_ACCOUNT_NOT_FOUND
+		case http.StatusUnauthorized:
+			return commonConsts.ERROR_CODE_ACCOUNT_PRIVATE
+		}
+	}
+	if errors.Is(err, ErrPrivateAddress) {
+		// Provided by user, like feed URL
+		return commonConsts.ERROR_CODE_INVALID_FORMAT
+	}
+	return commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED
+}
@@ -0,0 +1,215 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrCircuitOpen = errors.New("circuit open")
+	ErrRateLimited = errors.New("rate limited")
+)
+
+// hostGuard : Token bucket and circuit breaker of one upstream host
+type hostGuard struct {
+	lock sync.Mutex
+
+	// Token bucket
+	tokens     float64
+	refilledAt time.Time
+
+	// Circuit breaker
+	failures       int       // Consecutive failures
+	opened         int       // Times opened without success in between, for cooldown backoff
+	openUntil      time.Time // Also extended by Retry-After
+	isTrialRunning bool      // Half open, only one request allowed
+}
+
+var (
+	guardsLock  sync.Mutex
+	guards      = make(map[string]*hostGuard) // host : guard
+	exemptHosts = make(map[string]bool)
+)
+
+// ExemptHost : Skip rate limiting and circuit breaking for hosts of our own services (like IPFS relay)
+func ExemptHost(rawURL string) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return
+	}
+
+	guardsLock.Lock()
+	defer guardsLock.Unlock()
+
+	exemptHosts[u.Host] = true
+}
+
+func guardFor(host string) *hostGuard {
+	guardsLock.Lock()
+	defer guardsLock.Unlock()
+
+	if exemptHosts[host] {
+		return nil
+	}
+
+	g, ok := guards[host]
+	if !ok {
+		g = &hostGuard{
+			tokens:     float64(config.Config.HostRateBurst),
+			refilledAt: time.Now(),
+		}
+		guards[host] = g
+	}
+
+	return g
+}
+
+// acquire : Wait for a token, fails fast if circuit is open
+func (g *hostGuard) acquire(ctx context.Context, host string) error {
+	g.lock.Lock()
+
+	now := time.Now()
+	if now.Before(g.openUntil) {
+		g.lock.Unlock()
+		return fmt.Errorf("%w: %s until %s", ErrCircuitOpen, host, g.openUntil.Format(time.RFC3339))
+	}
+	if g.opened > 0 {
+		// Half open
+		if g.isTrialRunning {
+			g.lock.Unlock()
+			return fmt.Errorf("%w: %s is being probed", ErrCircuitOpen, host)
+		}
+		g.isTrialRunning = true
+	}
+
+	rate := config.Config.HostRateLimit
+	if rate <= 0 {
+		// Unlimited
+		g.lock.Unlock()
+		return nil
+	}
+
+	burst := float64(config.Config.HostRateBurst)
+	if burst < 1 {
+		burst = 1
+	}
+	g.tokens += now.Sub(g.refilledAt).Seconds() * rate
+	if g.tokens > burst {
+		g.tokens = burst
+	}
+	g.refilledAt = now
+
+	// Reserve a token, might be borrowed from future
+	g.tokens--
+	var wait time.Duration
+	if g.tokens < 0 {
+		wait = time.Duration(-g.tokens / rate * float64(time.Second))
+	}
+	if wait > consts.HOST_RATE_LIMIT_MAX_WAIT {
+		g.tokens++
+		g.isTrialRunning = false
+		g.lock.Unlock()
+		return fmt.Errorf("%w: %s", ErrRateLimited, host)
+	}
+
+	g.lock.Unlock()
+
+	if wait > 0 {
+		select {
+		case <-ctx.Done():
+			g.lock.Lock()
+			g.isTrialRunning = false
+			g.lock.Unlock()
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return nil
+}
+
+// record : Update circuit with request result
+func (g *hostGuard) record(err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.isTrialRunning = false
+	now := time.Now()
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		// Told by upstream
+		if until := now.Add(statusErr.RetryAfter); until.After(g.openUntil) {
+			g.openUntil = until
+		}
+	}
+
+	if !isHostFault(err) {
+		g.failures = 0
+		g.opened = 0
+		return
+	}
+
+	threshold := config.Config.CircuitBreakerThreshold
+	if threshold <= 0 {
+		threshold = consts.CONFIG_DEFAULT_CIRCUIT_BREAKER_THRESHOLD
+	}
+
+	g.failures++
+	if g.opened > 0 || g.failures >= threshold {
+		// Open (again)
+		g.opened++
+		g.failures = 0
+		cooldown := consts.CIRCUIT_BREAKER_COOLDOWN_BASE << (g.opened - 1)
+		if cooldown > consts.CIRCUIT_BREAKER_COOLDOWN_MAX || cooldown <= 0 {
+			cooldown = consts.CIRCUIT_BREAKER_COOLDOWN_MAX
+		}
+		if until := now.Add(cooldown); until.After(g.openUntil) {
+			g.openUntil = until
+		}
+	}
+}
+
+// isHostFault : Upstream unavailable or overloaded
+func isHostFault(err error) bool {
+	if err == nil || errors.Is(err, ErrBodyTooLarge) || errors.Is(err, ErrPrivateAddress) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	// Network errors, timeout
+	return true
+}
+
+// parseRetryAfter : In seconds or HTTP date, limited to a reasonable range
+func parseRetryAfter(raw string) time.Duration {
+	if raw == "" {
+		return 0
+	}
+
+	var retryAfter time.Duration
+	if seconds, err := strconv.Atoi(raw); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(raw); err == nil {
+		retryAfter = time.Until(at)
+	}
+
+	if retryAfter < 0 {
+		return 0
+	} else if retryAfter > consts.HTTP_RETRY_AFTER_MAX {
+		return consts.HTTP_RETRY_AFTER_MAX
+	}
+	return retryAfter
+}
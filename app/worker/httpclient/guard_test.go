@@ -0,0 +1,75 @@
+package httpclient
+
+import (
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGuard(t *testing.T) {
+	config.Config.HttpRetries = 0
+	config.Config.CircuitBreakerThreshold = 2
+	config.Config.HostRateLimit = 0
+	defer func() {
+		config.Config.CircuitBreakerThreshold = 0
+	}()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	TrustHost(server.URL)
+
+	// Circuit opens after consecutive failures
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		if _, err := Do(req, false); !IsStatus(err, http.StatusBadGateway) {
+			t.Fatalf("should be bad gateway, got %v", err)
+		}
+	}
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if _, err := Do(req, false); !errors.Is(err, ErrCircuitOpen) || ErrorCode(err) != commonConsts.ERROR_CODE_CIRCUIT_OPEN {
+		t.Fatalf("should be circuit open, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("should not reach upstream when circuit open, got %d requests", requests)
+	}
+
+	// Retry-After is honoured
+	g := &hostGuard{}
+	g.record(&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour})
+	if err := g.acquire(req.Context(), "busy"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("should wait as told by upstream, got %v", err)
+	}
+
+	// Too long to wait for a token
+	config.Config.HostRateLimit = 0.01
+	defer func() {
+		config.Config.HostRateLimit = 0
+	}()
+	g = &hostGuard{tokens: 1, refilledAt: time.Now()}
+	if err := g.acquire(req.Context(), "slow"); err != nil {
+		t.Fatalf("should have token, got %v", err)
+	}
+	if err := g.acquire(req.Context(), "slow"); !errors.Is(err, ErrRateLimited) || ErrorCode(err) != commonConsts.ERROR_CODE_RATE_LIMITED {
+		t.Errorf("should be rate limited, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if parseRetryAfter("120") != 2*time.Minute {
+		t.Error("should parse seconds")
+	}
+	if d := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); d <= 0 || d > time.Minute {
+		t.Errorf("should parse http date, got %s", d)
+	}
+	if parseRetryAfter("invalid") != 0 {
+		t.Error("should ignore invalid value")
+	}
+}
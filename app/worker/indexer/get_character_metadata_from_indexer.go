@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"net/http"
 )
@@ -23,7 +24,7 @@ func GetCharacterMetadataFromIndexer(characterIdStr string) (*types.CharacterMet
 	}
 
 	// Execute request
-	res, err := (&http.Client{}).Do(req)
+	res, err := httpclient.Do(req, false)
 	if err != nil {
 		global.Logger.Errorf("Failed to execute request for get character data (%s) from indexer with error: %s", characterIdStr, err.Error())
 		return nil, err
@@ -31,7 +32,7 @@ func GetCharacterMetadataFromIndexer(characterIdStr string) (*types.CharacterMet
 
 	// Parse response
 	var resBody characterMetadataIndexerResponse
-	err = json.NewDecoder(res.Body).Decode(&resBody)
+	err = json.Unmarshal(res.Body, &resBody)
 	if err != nil {
 		global.Logger.Errorf("Failed to parse response for get character data (%s) from indexer with error: %s", characterIdStr, err.Error())
 		return nil, err
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
 	"net/http"
 )
 
@@ -39,7 +40,7 @@ func GetFeedWithLinkFromIndexer(link string) (string, string, int64, int64, erro
 	req.URL.RawQuery = q.Encode()
 
 	// Execute request
-	rawRes, err := (&http.Client{}).Do(req)
+	rawRes, err := httpclient.Do(req, false)
 	if err != nil {
 		global.Logger.Errorf("Failed to execute request for indexer query %s with error: %s", link, err.Error())
 		return "", "", 0, 0, err
@@ -47,7 +48,7 @@ func GetFeedWithLinkFromIndexer(link string) (string, string, int64, int64, erro
 
 	// Parse response
 	var res feedWithLinkIndexerResponse
-	err = json.NewDecoder(rawRes.Body).Decode(&res)
+	err = json.Unmarshal(rawRes.Body, &res)
 	if err != nil {
 		global.Logger.Errorf("Failed to parse request for indexer query %s with error: %s", link, err.Error())
 		return "", "", 0, 0, err
@@ -0,0 +1,15 @@
+package inits
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/concurrency"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+)
+
+func Concurrency() {
+	concurrency.Init(map[commonTypes.ConcurrencyClass]int{
+		commonTypes.ConcurrencyClassStateful:  config.Config.ConcurrencyStateful,
+		commonTypes.ConcurrencyClassStateless: config.Config.ConcurrencyStateless,
+		commonTypes.ConcurrencyClassDirect:    config.Config.ConcurrencyDirect,
+	}, config.Config.ConcurrencyPlatforms, config.Config.ConcurrencyWeights)
+}
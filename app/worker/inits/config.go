@@ -4,25 +4,62 @@ import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"log"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func Config() error {
 	var exist bool
 	var err error
 
-	if config.Config.RSSHubEndpointStateful, exist = os.LookupEnv("RSSHUB_STATEFUL"); !exist {
+	if config.Config.WorkerID, exist = os.LookupEnv("WORKER_ID"); !exist {
+		if config.Config.WorkerID, err = os.Hostname(); err != nil {
+			return fmt.Errorf("please specify worker ID, failed to get hostname: %v", err)
+		}
+	}
+
+	if workerPlatforms, exist := os.LookupEnv("WORKER_PLATFORMS"); !exist || strings.TrimSpace(workerPlatforms) == "" {
+		config.Config.Platforms = platforms.IDs()
+	} else {
+		for _, platformID := range strings.Split(workerPlatforms, ",") {
+			if platformID = strings.TrimSpace(platformID); platformID == "" {
+				continue
+			} else if _, ok := platforms.Get(platformID); !ok {
+				return fmt.Errorf("platform %s to serve is not supported", platformID)
+			}
+			config.Config.Platforms = append(config.Config.Platforms, platformID)
+		}
+	}
+
+	if rssHubStateful, exist := os.LookupEnv("RSSHUB_STATEFUL"); !exist {
 		return fmt.Errorf("please specify endpoint URI for stateful RSSHub (https://rsshub.app)")
+	} else if config.Config.RSSHubEndpointsStateful = splitEndpoints(rssHubStateful); len(config.Config.RSSHubEndpointsStateful) == 0 {
+		return fmt.Errorf("please specify at least one endpoint URI for stateful RSSHub (https://rsshub.app)")
 	}
-	if config.Config.RSSHubEndpointStateless, exist = os.LookupEnv("RSSHUB_STATELESS"); !exist {
+	if rssHubStateless, exist := os.LookupEnv("RSSHUB_STATELESS"); !exist {
 		return fmt.Errorf("please specify endpoint URI for stateless RSSHub (https://rsshub.app)")
+	} else if config.Config.RSSHubEndpointsStateless = splitEndpoints(rssHubStateless); len(config.Config.RSSHubEndpointsStateless) == 0 {
+		return fmt.Errorf("please specify at least one endpoint URI for stateless RSSHub (https://rsshub.app)")
 	}
 
+	if config.Config.BlueskyPDSEndpoint, exist = os.LookupEnv("BLUESKY_PDS_ENDPOINT"); !exist {
+		config.Config.BlueskyPDSEndpoint = consts.CONFIG_DEFAULT_BLUESKY_PDS_ENDPOINT
+	}
+	config.Config.BlueskyPDSEndpoint = strings.TrimSuffix(config.Config.BlueskyPDSEndpoint, "/")
+
+	nostrRelays, exist := os.LookupEnv("NOSTR_RELAYS")
+	if !exist {
+		nostrRelays = consts.CONFIG_DEFAULT_NOSTR_RELAYS
+	}
+	config.Config.NostrRelays = splitEndpoints(nostrRelays)
+
 	if rawProxyURL, exist := os.LookupEnv("PROXY_URL"); !exist {
 		log.Println("Http proxy URL not set, skip proxy")
 		config.Config.ProxyURL = nil
@@ -31,6 +68,60 @@ func Config() error {
 		config.Config.ProxyURL = nil
 	}
 
+	if httpTimeoutStr, exist := os.LookupEnv("HTTP_TIMEOUT"); !exist {
+		config.Config.HttpTimeout = consts.CONFIG_DEFAULT_HTTP_TIMEOUT // Default
+	} else if config.Config.HttpTimeout, err = time.ParseDuration(httpTimeoutStr); err != nil || config.Config.HttpTimeout <= 0 {
+		log.Println("Invalid http timeout settings, using default value")
+		config.Config.HttpTimeout = consts.CONFIG_DEFAULT_HTTP_TIMEOUT // Default
+	}
+	if httpMaxBodySizeStr, exist := os.LookupEnv("HTTP_MAX_BODY_SIZE"); !exist {
+		config.Config.HttpMaxBodySize = consts.CONFIG_DEFAULT_HTTP_MAX_BODY_SIZE // Default
+	} else if config.Config.HttpMaxBodySize, err = strconv.ParseInt(httpMaxBodySizeStr, 10, 64); err != nil || config.Config.HttpMaxBodySize <= 0 {
+		log.Println("Invalid http max body size settings, using default value")
+		config.Config.HttpMaxBodySize = consts.CONFIG_DEFAULT_HTTP_MAX_BODY_SIZE // Default
+	}
+	if config.Config.HttpUserAgent, exist = os.LookupEnv("HTTP_USER_AGENT"); !exist {
+		config.Config.HttpUserAgent = consts.CONFIG_DEFAULT_HTTP_USER_AGENT // Default
+	}
+	if httpRetriesStr, exist := os.LookupEnv("HTTP_RETRIES"); !exist {
+		config.Config.HttpRetries = consts.CONFIG_DEFAULT_HTTP_RETRIES // Default
+	} else if config.Config.HttpRetries, err = strconv.Atoi(httpRetriesStr); err != nil || config.Config.HttpRetries < 0 {
+		log.Println("Invalid http retries settings, using default value")
+		config.Config.HttpRetries = consts.CONFIG_DEFAULT_HTTP_RETRIES // Default
+	}
+
+	if mediaTimeoutStr, exist := os.LookupEnv("MEDIA_TIMEOUT"); !exist {
+		config.Config.MediaTimeout = consts.CONFIG_DEFAULT_MEDIA_TIMEOUT // Default
+	} else if config.Config.MediaTimeout, err = time.ParseDuration(mediaTimeoutStr); err != nil || config.Config.MediaTimeout <= 0 {
+		log.Println("Invalid media timeout settings, using default value")
+		config.Config.MediaTimeout = consts.CONFIG_DEFAULT_MEDIA_TIMEOUT // Default
+	}
+	if mediaMaxBodySizeStr, exist := os.LookupEnv("MEDIA_MAX_BODY_SIZE"); !exist {
+		config.Config.MediaMaxBodySize = consts.CONFIG_DEFAULT_MEDIA_MAX_BODY_SIZE // Default
+	} else if config.Config.MediaMaxBodySize, err = strconv.ParseInt(mediaMaxBodySizeStr, 10, 64); err != nil || config.Config.MediaMaxBodySize <= 0 {
+		log.Println("Invalid media max body size settings, using default value")
+		config.Config.MediaMaxBodySize = consts.CONFIG_DEFAULT_MEDIA_MAX_BODY_SIZE // Default
+	}
+
+	if hostRateLimitStr, exist := os.LookupEnv("HOST_RATE_LIMIT"); !exist {
+		config.Config.HostRateLimit = consts.CONFIG_DEFAULT_HOST_RATE_LIMIT // Default
+	} else if config.Config.HostRateLimit, err = strconv.ParseFloat(hostRateLimitStr, 64); err != nil || config.Config.HostRateLimit < 0 {
+		log.Println("Invalid host rate limit settings, using default value")
+		config.Config.HostRateLimit = consts.CONFIG_DEFAULT_HOST_RATE_LIMIT // Default
+	}
+	if hostRateBurstStr, exist := os.LookupEnv("HOST_RATE_BURST"); !exist {
+		config.Config.HostRateBurst = consts.CONFIG_DEFAULT_HOST_RATE_BURST // Default
+	} else if config.Config.HostRateBurst, err = strconv.Atoi(hostRateBurstStr); err != nil || config.Config.HostRateBurst <= 0 {
+		log.Println("Invalid host rate burst settings, using default value")
+		config.Config.HostRateBurst = consts.CONFIG_DEFAULT_HOST_RATE_BURST // Default
+	}
+	if circuitBreakerThresholdStr, exist := os.LookupEnv("CIRCUIT_BREAKER_THRESHOLD"); !exist {
+		config.Config.CircuitBreakerThreshold = consts.CONFIG_DEFAULT_CIRCUIT_BREAKER_THRESHOLD // Default
+	} else if config.Config.CircuitBreakerThreshold, err = strconv.Atoi(circuitBreakerThresholdStr); err != nil || config.Config.CircuitBreakerThreshold <= 0 {
+		log.Println("Invalid circuit breaker threshold settings, using default value")
+		config.Config.CircuitBreakerThreshold = consts.CONFIG_DEFAULT_CIRCUIT_BREAKER_THRESHOLD // Default
+	}
+
 	if config.Config.IPFSEndpoint, exist = os.LookupEnv("IPFS_ENDPOINT"); !exist {
 		return fmt.Errorf("please specify endpoint URI for your IPFS-Upload-Relay instance (https://github.com/NaturalSelectionLabs/IPFS-Upload-Relay)")
 	}
@@ -41,10 +132,29 @@ func Config() error {
 	if config.Config.MQConnString, exist = os.LookupEnv("MQ_CONNECTION_STRING"); !exist {
 		config.Config.MQConnString = commonConsts.CONFIG_DEFAULT_MQ_CONNECTION_STRING
 	}
+	if mqPrefetchStr, exist := os.LookupEnv("MQ_PREFETCH"); !exist {
+		config.Config.MQPrefetch = commonConsts.CONFIG_DEFAULT_MQ_PREFETCH // Default
+	} else if config.Config.MQPrefetch, err = strconv.Atoi(mqPrefetchStr); err != nil || config.Config.MQPrefetch <= 0 {
+		log.Println("Invalid MQ prefetch settings, using default value")
+		config.Config.MQPrefetch = commonConsts.CONFIG_DEFAULT_MQ_PREFETCH // Default
+	}
+	if mqMaxRedeliveryStr, exist := os.LookupEnv("MQ_MAX_REDELIVERY"); !exist {
+		config.Config.MQMaxRedelivery = commonConsts.CONFIG_DEFAULT_MQ_MAX_REDELIVERY // Default
+	} else if config.Config.MQMaxRedelivery, err = strconv.Atoi(mqMaxRedeliveryStr); err != nil || config.Config.MQMaxRedelivery < 0 {
+		log.Println("Invalid MQ max redelivery settings, using default value")
+		config.Config.MQMaxRedelivery = commonConsts.CONFIG_DEFAULT_MQ_MAX_REDELIVERY // Default
+	}
+	if shutdownTimeoutStr, exist := os.LookupEnv("SHUTDOWN_TIMEOUT"); !exist {
+		config.Config.ShutdownTimeout = commonConsts.CONFIG_DEFAULT_SHUTDOWN_TIMEOUT // Default
+	} else if config.Config.ShutdownTimeout, err = time.ParseDuration(shutdownTimeoutStr); err != nil || config.Config.ShutdownTimeout <= 0 {
+		log.Println("Invalid shutdown timeout settings, using default value")
+		config.Config.ShutdownTimeout = commonConsts.CONFIG_DEFAULT_SHUTDOWN_TIMEOUT // Default
+	}
 	if config.Config.WorkerRPCPort, exist = os.LookupEnv("WORKER_RPC_PORT"); !exist {
 		config.Config.WorkerRPCPort = commonConsts.CONFIG_DEFAULT_WORKER_RPC_PORT
 	}
 
+	config.Config.PlatformSettingsFile = os.Getenv("PLATFORM_SETTINGS_FILE") // Optional
 	if concurrencyStatefulStr, exist := os.LookupEnv("CONCURRENCY_CONTROL_STATEFUL"); !exist {
 		config.Config.ConcurrencyStateful = consts.CONFIG_DEFAULT_CONCURRENCY_CONTROL_STATEFUL // Default
 	} else if config.Config.ConcurrencyStateful, err = strconv.Atoi(concurrencyStatefulStr); err != nil || config.Config.ConcurrencyStateful <= 0 {
@@ -63,6 +173,17 @@ func Config() error {
 		log.Println("Invalid direct concurrency control settings, using default value")
 		config.Config.ConcurrencyDirect = consts.CONFIG_DEFAULT_CONCURRENCY_CONTROL_DIRECT // Default
 	}
+	if config.Config.ConcurrencyPlatforms, err = parsePlatformNumbers(os.Getenv("CONCURRENCY_CONTROL_PLATFORMS"), 0); err != nil {
+		return fmt.Errorf("invalid platform concurrency control settings: %v", err)
+	}
+	if config.Config.ConcurrencyWeights, err = parsePlatformNumbers(os.Getenv("CONCURRENCY_CONTROL_WEIGHTS"), 1); err != nil {
+		return fmt.Errorf("invalid platform concurrency weight settings: %v", err)
+	}
+
+	config.Config.WorkerAdminToken = os.Getenv("WORKER_ADMIN_TOKEN") // Admin endpoints disabled if empty
+	if config.Config.WorkerAdminPort, exist = os.LookupEnv("WORKER_ADMIN_PORT"); !exist {
+		config.Config.WorkerAdminPort = consts.CONFIG_DEFAULT_WORKER_ADMIN_PORT
+	}
 
 	config.Config.DevelopmentMode = !strings.Contains(strings.ToLower(os.Getenv("MODE")), "prod")
 
@@ -100,3 +221,40 @@ func Config() error {
 	return nil
 
 }
+
+// splitEndpoints : Comma separated endpoints, trailing slashes removed
+func splitEndpoints(raw string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(raw, ",") {
+		if endpoint = strings.TrimSuffix(strings.TrimSpace(endpoint), "/"); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// parsePlatformNumbers : Comma separated platform=number pairs, numbers should be at least min
+func parsePlatformNumbers(raw string, min int) (map[string]int, error) {
+	numbers := make(map[string]int)
+	for _, pair := range strings.Split(raw, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		platformID, numberStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s should be in platform=number format", pair)
+		}
+		platformID = strings.TrimSpace(platformID)
+		if !commonPlatforms.IsSupported(platformID) {
+			return nil, fmt.Errorf("platform %s not supported", platformID)
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(numberStr))
+		if err != nil || number < min {
+			return nil, fmt.Errorf("%s should be an integer not less than %d", pair, min)
+		}
+
+		numbers[platformID] = number
+	}
+	return numbers, nil
+}
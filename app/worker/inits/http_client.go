@@ -0,0 +1,33 @@
+package inits
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+)
+
+func HttpClient() {
+	// Our own services, no need to protect.
+	// RSSHub routes fail on their own, endpoint health is tracked by the pool instead
+	httpclient.ExemptHost(config.Config.IPFSEndpoint)
+	for _, endpoint := range config.Config.RSSHubEndpointsStateful {
+		httpclient.ExemptHost(endpoint)
+	}
+	for _, endpoint := range config.Config.RSSHubEndpointsStateless {
+		httpclient.ExemptHost(endpoint)
+	}
+
+	// Our own services, might be in private network
+	httpclient.TrustHost(config.Config.IPFSEndpoint)
+	for _, endpoint := range config.Config.RSSHubEndpointsStateful {
+		httpclient.TrustHost(endpoint)
+	}
+	for _, endpoint := range config.Config.RSSHubEndpointsStateless {
+		httpclient.TrustHost(endpoint)
+	}
+	for _, relay := range config.Config.NostrRelays {
+		httpclient.TrustHost(relay)
+	}
+	if config.Config.ProxyURL != nil {
+		httpclient.TrustHost(config.Config.ProxyURL.String())
+	}
+}
@@ -1,6 +1,8 @@
 package inits
 
 import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/admin"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/metrics"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/mq/jobs"
 )
 
@@ -9,5 +11,10 @@ func Jobs() error {
 		return err
 	}
 
+	metrics.StartReporting()
+	metrics.StartHeartbeat()
+
+	admin.Start()
+
 	return nil
 }
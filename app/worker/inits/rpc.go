@@ -6,8 +6,10 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/rpc/service"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"net"
 	"net/rpc"
+	"sync"
 )
 
 func RPC() error {
@@ -22,6 +24,27 @@ func RPC() error {
 		return err
 	}
 
+	var (
+		conns     = make(map[net.Conn]struct{})
+		connsLock sync.Mutex
+	)
+
+	shutdown.OnStop(func() {
+		// No new connections
+		_ = listener.Close()
+
+		// No new requests on existing connections, they are closed after responses of requests in progress sent
+		connsLock.Lock()
+		defer connsLock.Unlock()
+		for conn := range conns {
+			if tcpConn, ok := conn.(*net.TCPConn); ok {
+				_ = tcpConn.CloseRead()
+			} else {
+				_ = conn.Close()
+			}
+		}
+	})
+
 	go func() {
 		global.Logger.Debug("Start waiting for RPC connections...")
 
@@ -30,9 +53,17 @@ func RPC() error {
 
 			conn, err := listener.Accept() // Block till next incoming connection
 			if err != nil {
+				if shutdown.Draining() {
+					global.Logger.Info("RPC listener closed.")
+					return
+				}
 				global.Logger.Fatalf("Failed to accept connections with error: %s", err.Error())
 			}
 
+			connsLock.Lock()
+			conns[conn] = struct{}{}
+			connsLock.Unlock()
+
 			// Start a new session to serve
 			go func() {
 				global.Logger.Debug("A new RPC connection starting...")
@@ -41,6 +72,10 @@ func RPC() error {
 
 				rpc.ServeConn(conn)
 
+				connsLock.Lock()
+				delete(conns, conn)
+				connsLock.Unlock()
+
 				// If ended
 				global.Logger.Debug("An RPC connection closed.")
 			}()
@@ -0,0 +1,10 @@
+package inits
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/rsshub"
+)
+
+func RSSHub() {
+	rsshub.Init(config.Config.RSSHubEndpointsStateful, config.Config.RSSHubEndpointsStateless)
+}
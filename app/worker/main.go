@@ -4,7 +4,9 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/inits"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/builtin" // Register built-in platforms
 	commonInits "github.com/Crossbell-Box/OperatorSync/common/inits"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"log"
 )
 
@@ -24,11 +26,25 @@ func main() {
 
 	global.Logger.Info("Logger initialized, switch to here.")
 
+	// Initialize HTTP client
+	inits.HttpClient()
+
+	// Initialize RSSHub endpoints
+	inits.RSSHub()
+
+	// Initialize concurrency limits
+	inits.Concurrency()
+
 	// Initialize redis
 	if err := commonInits.Redis(config.Config.RedisConnString); err != nil {
 		global.Logger.Fatal("Failed to load redis: ", err.Error())
 	}
 
+	// Initialize platform settings
+	if err := commonInits.PlatformSettings(config.Config.PlatformSettingsFile, global.Logger); err != nil {
+		global.Logger.Fatal("Failed to load platform settings: ", err.Error())
+	}
+
 	// Initialize MQ
 	if err := commonInits.MQ(config.Config.MQConnString, global.Logger); err != nil {
 		global.Logger.Fatal("Failed to load MQ: ", err.Error())
@@ -48,6 +64,7 @@ func main() {
 
 	global.Logger.Info("Worker started!")
 
-	select {} // Keep process running
+	// Keep process running till SIGTERM, then finish works in progress
+	shutdown.Wait(global.Logger, config.Config.ShutdownTimeout)
 
 }
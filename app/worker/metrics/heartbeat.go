@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/mq/jobs"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+// Beat : Tell server this worker is alive, with platforms served and current load
+func Beat(ctx context.Context) error {
+	heartbeatBytes, err := json.Marshal(&commonTypes.WorkerHeartbeat{
+		BeatAt:    time.Now(),
+		Platforms: config.Config.Platforms,
+		Load: commonTypes.WorkerLoad{
+			InFlight: jobs.WorksInFlight(),
+			Prefetch: jobs.WorksPrefetch(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return commonGlobal.Redis.HSet(ctx, commonConsts.REDIS_WorkerHeartbeatKey, config.Config.WorkerID, heartbeatBytes).Err()
+}
+
+// StartHeartbeat : Beat periodically, and leave once draining so server stops counting on this worker
+func StartHeartbeat() {
+	shutdown.OnStop(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), consts.HEARTBEAT_INTERVAL)
+		defer cancel()
+
+		if err := commonGlobal.Redis.HDel(ctx, commonConsts.REDIS_WorkerHeartbeatKey, config.Config.WorkerID).Err(); err != nil {
+			global.Logger.Errorf("Failed to remove worker heartbeat with error: %s", err.Error())
+		}
+	})
+
+	go func() {
+		for {
+			if shutdown.Draining() {
+				// Already left
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), consts.HEARTBEAT_INTERVAL)
+			if err := Beat(ctx); err != nil {
+				global.Logger.Errorf("Failed to send worker heartbeat with error: %s", err.Error())
+			}
+			cancel()
+
+			select {
+			case <-shutdown.Stopping():
+				return
+			case <-time.After(consts.HEARTBEAT_INTERVAL):
+			}
+		}
+	}()
+}
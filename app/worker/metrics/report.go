@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/concurrency"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/rsshub"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+// Collect : Current metrics of this worker
+func Collect() *commonTypes.WorkerMetrics {
+	return &commonTypes.WorkerMetrics{
+		ReportedAt:  time.Now(),
+		RSSHub:      rsshub.Stats(),
+		Concurrency: concurrency.Stats(),
+	}
+}
+
+// Report : Save metrics to redis, so server can list all workers
+func Report(ctx context.Context) error {
+	metricsBytes, err := json.Marshal(Collect())
+	if err != nil {
+		return err
+	}
+
+	return commonGlobal.Redis.HSet(ctx, commonConsts.REDIS_WorkerMetricsKey, config.Config.WorkerID, metricsBytes).Err()
+}
+
+// StartReporting : Report metrics periodically, and remove them on shutdown
+func StartReporting() {
+	shutdown.OnClose(func(ctx context.Context) {
+		if err := commonGlobal.Redis.HDel(ctx, commonConsts.REDIS_WorkerMetricsKey, config.Config.WorkerID).Err(); err != nil {
+			global.Logger.Errorf("Failed to remove worker metrics with error: %s", err.Error())
+		}
+	})
+
+	go func() {
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), consts.METRICS_REPORT_INTERVAL)
+			if err := Report(ctx); err != nil {
+				global.Logger.Errorf("Failed to report worker metrics with error: %s", err.Error())
+			}
+			cancel()
+
+			select {
+			case <-shutdown.Stopping():
+				return
+			case <-time.After(consts.METRICS_REPORT_INTERVAL):
+			}
+		}
+	}()
+}
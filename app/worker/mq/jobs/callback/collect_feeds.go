@@ -5,14 +5,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	commonResults "github.com/Crossbell-Box/OperatorSync/common/results"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"time"
 )
 
-func FeedsHandleSucceeded(ch *amqp.Channel, qSucceededName string, workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, rawFeeds []commonTypes.RawFeed, newInterval time.Duration) {
+func FeedsHandleSucceeded(ch *amqp.Channel, qSucceededName string, workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, rawFeeds []commonTypes.RawFeed, gap *commonTypes.FeedGap) error {
 
 	global.Logger.Debug("Work succeeded: ", workDispatched)
 
@@ -26,24 +29,27 @@ func FeedsHandleSucceeded(ch *amqp.Channel, qSucceededName string, workDispatche
 		AcceptedAt:     acceptTime,
 		SucceededAt:    time.Now(),
 		Feeds:          rawFeeds,
-		NewInterval:    newInterval,
+		Gap:            gap,
 	}
 	if succeededWorkBytes, err := json.Marshal(&succeededWork); err != nil {
 		global.Logger.Error("Failed to marshall succeeded work: ", succeededWork)
+		return err
 	} else {
-		// Save result to redis
+		// Save result to redis, kept until server processed it
 		storageKey := fmt.Sprintf(commonConsts.REDIS_FeedCollectResultKeyTemplate, workDispatched.Platform, workDispatched.Username, workDispatched.DispatchAt.UnixNano())
-		commonGlobal.Redis.Set(context.Background(), storageKey, succeededWorkBytes, commonConsts.REDIS_FeedCollectResultExpires)
+		if err := commonResults.Save(context.Background(), commonGlobal.Redis, storageKey, succeededWorkBytes); err != nil {
+			global.Logger.Error("Failed to save succeeded work result with error: ", err.Error())
+			return err
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), commonConsts.MQSETTINGS_PublishTimeOut)
 		defer cancel()
 
-		err = ch.PublishWithContext(
+		err = commonMQ.PublishConfirmed(
 			ctx,
+			ch,
 			"",
 			qSucceededName,
-			false,
-			false,
 			amqp.Publishing{
 				DeliveryMode: amqp.Persistent,
 				ContentType:  "text/plain",
@@ -55,45 +61,75 @@ func FeedsHandleSucceeded(ch *amqp.Channel, qSucceededName string, workDispatche
 		)
 		if err != nil {
 			global.Logger.Error("Failed to report succeeded work with error: ", err.Error())
+			return err
 		} else {
 			global.Logger.Debug("And result pushed to succeeded channel.")
 		}
 	}
+
+	return nil
 }
 
-func FeedsHandleFailed(ch *amqp.Channel, qFailedName string, workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, errCode uint, errMsg string) {
+func FeedsHandleFailed(ch *amqp.Channel, qFailedName string, workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, errCode uint, errMsg string) error {
 	global.Logger.Warn("Work failed: ", workDispatched, " with code: ", errCode, " , reason: ", errMsg)
 
-	failedWork := commonTypes.WorkFailed{
+	return reportFailed(ch, qFailedName, commonConsts.MQSETTINGS_FeedCollectFailedIdentifier, newFailedWork(workDispatched, acceptTime, errCode, errMsg))
+}
+
+// FeedsHandlePanicked : Collector panicked, report as failed with stack trace and the item in progress
+func FeedsHandlePanicked(ch *amqp.Channel, qFailedName string, workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, workPanic *utils.WorkPanic) error {
+	failedWork := newFailedWork(workDispatched, acceptTime, commonConsts.ERROR_CODE_WORK_PANICKED, workPanic.Error())
+	failedWork.ItemID = workPanic.ItemID
+	failedWork.Stack = workPanic.Stack
+
+	return reportFailed(ch, qFailedName, commonConsts.MQSETTINGS_FeedCollectFailedIdentifier, failedWork)
+}
+
+// FeedsHandleExpired : Work discarded without processing, so server can reschedule it
+func FeedsHandleExpired(ch *amqp.Channel, qExpiredName string, workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, reason string) error {
+	global.Logger.Warn("Work expired: ", workDispatched, " , reason: ", reason)
+
+	return reportFailed(ch, qExpiredName, commonConsts.MQSETTINGS_FeedCollectExpiredIdentifier, newFailedWork(workDispatched, acceptTime, commonConsts.ERROR_CODE_WORK_EXPIRED, reason))
+}
+
+func newFailedWork(workDispatched *commonTypes.WorkDispatched, acceptTime time.Time, errCode uint, errMsg string) *commonTypes.WorkFailed {
+	return &commonTypes.WorkFailed{
 		WorkDispatched: *workDispatched,
 		AcceptAt:       acceptTime,
 		ErrorAt:        time.Now(),
 		ErrorCode:      errCode,
 		ErrorReason:    errMsg,
 	}
-	if failedWorkBytes, err := json.Marshal(&failedWork); err != nil {
+}
+
+func reportFailed(ch *amqp.Channel, qFailedName string, identifier string, failedWork *commonTypes.WorkFailed) error {
+
+	if failedWorkBytes, err := json.Marshal(failedWork); err != nil {
 		global.Logger.Error("Failed to marshall failed work: ", failedWork)
+		return err
 	} else {
 		ctx, cancel := context.WithTimeout(context.Background(), commonConsts.MQSETTINGS_PublishTimeOut)
 		defer cancel()
 
-		err = ch.PublishWithContext(
+		err = commonMQ.PublishConfirmed(
 			ctx,
+			ch,
 			"",
 			qFailedName,
-			false,
-			false,
 			amqp.Publishing{
 				DeliveryMode: amqp.Persistent,
 				ContentType:  "application/json",
 				Headers: amqp.Table{
-					commonConsts.MQSETTINGS_FeedCollectIdentifierField: commonConsts.MQSETTINGS_FeedCollectFailedIdentifier,
+					commonConsts.MQSETTINGS_FeedCollectIdentifierField: identifier,
 				},
 				Body: failedWorkBytes,
 			},
 		)
 		if err != nil {
 			global.Logger.Error("Failed to report failed work with error: ", err.Error())
+			return err
 		}
 	}
+
+	return nil
 }
@@ -2,30 +2,32 @@ package dispatch
 
 import (
 	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/concurrency"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/mq/jobs/callback"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/jike"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/mastodon"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/medium"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/pinterest"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/pixiv"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/substack"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/tg_channel"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/tiktok"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/twitter"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/y2b_channel"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"time"
 )
 
-func ProcessFeeds(cccs *types.ConcurrencyChannels, ch *amqp.Channel, qRetrieveName string, d *amqp.Delivery) {
+func ProcessFeeds(ch *amqp.Channel, qRetrieveName string, d *amqp.Delivery) {
 
 	global.Logger.Debug("New work received: ", string(d.Body))
 
+	if ok, err := commonMQ.CheckRedelivered(ch, d, config.Config.MQMaxRedelivery); err != nil {
+		global.Logger.Errorf("Failed to handle redelivered work with error: %s", err.Error())
+		return
+	} else if !ok {
+		global.Logger.Warn("Work redelivered, might be interrupted last time: ", string(d.Body))
+		return
+	}
+
 	acceptTime := time.Now()
 
 	var workDispatched commonTypes.WorkDispatched
@@ -33,52 +35,74 @@ func ProcessFeeds(cccs *types.ConcurrencyChannels, ch *amqp.Channel, qRetrieveNa
 	if err := json.Unmarshal(d.Body, &workDispatched); err != nil {
 		global.Logger.Error("Failed to parse dispatched work data.", err.Error())
 		// Even unable to report to failed works cause work cannot be parsed
+		_ = d.Nack(false, false) // To dead letter queue
 		return
 	}
 
-	collectLink := commonConsts.SUPPORTED_PLATFORM[workDispatched.Platform].FeedLink
+	// Settle the work after result reported, or retry if failed to report
+	var reportErr error
+	defer func() {
+		if reportErr == nil {
+			_ = d.Ack(false)
+		} else if err := commonMQ.Retry(ch, d, config.Config.MQMaxRedelivery); err != nil {
+			global.Logger.Errorf("Failed to retry work with error: %s", err.Error())
+		}
+	}()
 
-	var (
-		isSucceeded bool
-		feeds       []commonTypes.RawFeed
-		errCode     uint
-		errMsg      string
-	)
+	// Report panics as failed, runs before settlement above
+	defer func() {
+		if workPanic := utils.RecoverWork(&workDispatched, recover()); workPanic != nil {
+			utils.DiscardHttpValidators(&workDispatched)
+			utils.DiscardFeedGap(&workDispatched)
+			reportErr = callback.FeedsHandlePanicked(ch, qRetrieveName, &workDispatched, acceptTime, workPanic)
+		}
+	}()
 
-	var feedCollectFunc func(*types.ConcurrencyChannels, *commonTypes.WorkDispatched, string) (bool, []commonTypes.RawFeed, uint, string)
+	if reason, expired := utils.IsWorkExpired(&workDispatched); expired {
+		// Stale after backlog, newer work would take over
+		reportErr = callback.FeedsHandleExpired(ch, qRetrieveName, &workDispatched, acceptTime, reason)
+		return
+	}
 
-	switch workDispatched.Platform {
-	case "medium":
-		feedCollectFunc = medium.Feeds
-	case "tiktok":
-		feedCollectFunc = tiktok.Feeds
-	case "pinterest":
-		feedCollectFunc = pinterest.Feeds
-	case "twitter":
-		feedCollectFunc = twitter.Feeds
-	case "tg_channel":
-		feedCollectFunc = tg_channel.Feeds
-	case "substack":
-		feedCollectFunc = substack.Feeds
-	case "pixiv":
-		feedCollectFunc = pixiv.Feeds
-	case "y2b_channel":
-		feedCollectFunc = y2b_channel.Feeds
-	case "mastodon":
-		feedCollectFunc = mastodon.Feeds
-	case "jike":
-		feedCollectFunc = jike.Feeds
-	default:
+	collector, ok := platforms.Get(workDispatched.Platform)
+	if !ok {
 		// Unable to handle
-		callback.FeedsHandleFailed(ch, qRetrieveName, &workDispatched, acceptTime, commonConsts.ERROR_CODE_UNSUPPORTED_PLATFORM, "Unsupported platform")
+		reportErr = callback.FeedsHandleFailed(ch, qRetrieveName, &workDispatched, acceptTime, commonConsts.ERROR_CODE_UNSUPPORTED_PLATFORM, "Unsupported platform")
+		return
+	} else if !commonPlatforms.IsEnabled(workDispatched.Platform) {
+		// Disabled by settings
+		reportErr = callback.FeedsHandleFailed(ch, qRetrieveName, &workDispatched, acceptTime, commonConsts.ERROR_CODE_PLATFORM_DISABLED, "Platform disabled")
 		return
 	}
 
-	isSucceeded, feeds, errCode, errMsg = feedCollectFunc(cccs, &workDispatched, collectLink)
+	platformMeta, _ := commonPlatforms.Meta(workDispatched.Platform)
+	collectLink := platformMeta.FeedLink
+
+	// Concurrency control
+	release := concurrency.Acquire(workDispatched.Platform)
+	if reason, expired := utils.IsWorkExpired(&workDispatched); expired {
+		// Expired while waiting
+		release()
+		reportErr = callback.FeedsHandleExpired(ch, qRetrieveName, &workDispatched, acceptTime, reason)
+		return
+	}
+	isSucceeded, feeds, errCode, errMsg := func() (bool, []commonTypes.RawFeed, uint, string) {
+		// Slots released even if panicked
+		defer release()
+		return collector.Feeds(&workDispatched, collectLink)
+	}()
 
 	if isSucceeded {
-		callback.FeedsHandleSucceeded(ch, qRetrieveName, &workDispatched, acceptTime, feeds, utils.CalcNewInterval(&workDispatched, feeds))
+		utils.CommitHttpValidators(&workDispatched)
+		reportErr = callback.FeedsHandleSucceeded(ch, qRetrieveName, &workDispatched, acceptTime, feeds, utils.TakeFeedGap(&workDispatched))
+	} else if errCode == commonConsts.ERROR_CODE_NOT_MODIFIED {
+		// Nothing changed since last succeeded work
+		utils.DiscardHttpValidators(&workDispatched)
+		utils.DiscardFeedGap(&workDispatched)
+		reportErr = callback.FeedsHandleSucceeded(ch, qRetrieveName, &workDispatched, acceptTime, nil, nil)
 	} else {
-		callback.FeedsHandleFailed(ch, qRetrieveName, &workDispatched, acceptTime, errCode, errMsg)
+		utils.DiscardHttpValidators(&workDispatched)
+		utils.DiscardFeedGap(&workDispatched)
+		reportErr = callback.FeedsHandleFailed(ch, qRetrieveName, &workDispatched, acceptTime, errCode, errMsg)
 	}
 }
@@ -1,26 +1,51 @@
 package jobs
 
 import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/concurrency"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/mq/jobs/dispatch"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonMQ "github.com/Crossbell-Box/OperatorSync/common/mq"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-func FeedCollectStartProcess() error {
+var (
+	// Works accepted and not settled yet
+	inFlight atomic.Int32
+
+	// Each platform consumes on its own channel, so prefetch of a busy platform never holds back others
+	channelsLock sync.Mutex
+	channels     = make(map[string]*amqp.Channel) // platform : channel
+)
 
-	cccs := &types.ConcurrencyChannels{
-		Stateful:  types.NewCtrl(config.Config.ConcurrencyStateful),
-		Stateless: types.NewCtrl(config.Config.ConcurrencyStateless),
-		Direct:    types.NewCtrl(config.Config.ConcurrencyDirect),
+// WorksInFlight : Dispatched works in progress
+func WorksInFlight() int {
+	return int(inFlight.Load())
+}
+
+// WorksPrefetch : Dispatched works able to be taken at once, of all platforms served
+func WorksPrefetch() int {
+	prefetch := 0
+	for _, platformID := range config.Config.Platforms {
+		prefetch += concurrency.Prefetch(platformID, config.Config.MQPrefetch)
 	}
+	return prefetch
+}
+
+func FeedCollectStartProcess() error {
 
 	global.Logger.Debug("Feed Collect start listen on dispatching works...")
 
+	// Follow limits changed at runtime
+	concurrency.OnChange(adjustPrefetch)
+
 	go func() {
 		for {
 			// Waiting for connection
@@ -33,67 +58,53 @@ func FeedCollectStartProcess() error {
 
 			global.Logger.Infof("Preparing running environment for Feed Collect queues...")
 
-			notifyClose := make(chan *amqp.Error)
-			// Prepare channel
+			// Prepare queues
 			ch, err := commonGlobal.MQ.Channel()
 			if err != nil {
 				global.Logger.Fatalf("Failed to open MQ Feeds Collect dispatch channel with error: %s", err.Error())
 			}
-			ch.NotifyClose(notifyClose)
-
-			// Prepare dispatch queue
-			qDispatch, err := ch.QueueDeclare(
-				commonConsts.MQSETTINGS_FeedCollectDispatchQueueName,
-				false,
-				false,
-				false,
-				false,
-				nil,
-			)
-			if err != nil {
-				global.Logger.Fatalf("Failed to prepare MQ Feeds Collect dispatch queue with error: %s", err.Error())
+			if err := commonMQ.DeclareFeedCollectTopology(ch); err != nil {
+				global.Logger.Fatalf("Failed to prepare MQ Feeds Collect queues with error: %s", err.Error())
 			}
+			_ = ch.Close()
 
-			// Prepare retrieve queue
-			qRetrieve, err := ch.QueueDeclare(
-				commonConsts.MQSETTINGS_FeedCollectRetrieveQueueName,
-				false,
-				false,
-				false,
-				false,
-				nil,
-			)
-			if err != nil {
-				global.Logger.Fatalf("Failed to prepare MQ Feeds Collect retrieve queue with error: %s", err.Error())
-			}
+			// Only works of platforms served are routed here
+			notifyClose := make(chan *amqp.Error, len(config.Config.Platforms))
+			platformChannels := make(map[string]*amqp.Channel)
+			for _, platformID := range config.Config.Platforms {
+				platformCh, deliveries, err := consumePlatform(platformID, notifyClose)
+				if err != nil {
+					global.Logger.Fatalf("Failed to subscribe to MQ Feeds Collect dispatching queue of %s with error: %s", platformID, err.Error())
+				}
+				platformChannels[platformID] = platformCh
 
-			deliveries, err := ch.Consume(
-				qDispatch.Name,
-				"",
-				true,
-				false,
-				false,
-				false,
-				nil,
-			)
-			if err != nil {
-				global.Logger.Fatalf("Failed to subscribe to MQ Feeds Collect dispatching queue with error: %s", err.Error())
+				go serve(deliveries, func(d *amqp.Delivery) {
+					dispatch.ProcessFeeds(platformCh, commonConsts.MQSETTINGS_FeedCollectRetrieveQueueName, d)
+				})
 			}
-			isPendingRestart := false
-			for {
-				if isPendingRestart {
-					break
-				}
-				select {
-				case d := <-deliveries:
-					go dispatch.ProcessFeeds(cccs, ch, qRetrieve.Name, &d)
-				case err := <-notifyClose:
-					if err != nil {
-						global.Logger.Errorf("MQ channel closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
-						isPendingRestart = true
+			channelsLock.Lock()
+			channels = platformChannels
+			channelsLock.Unlock()
+
+			select {
+			case <-shutdown.Stopping():
+				// Stop receiving new works, channels are kept open for works in progress to report
+				for platformID, platformCh := range platformChannels {
+					if err := platformCh.Cancel(consumerTag(platformID), false); err != nil {
+						global.Logger.Errorf("Failed to stop consuming dispatched works of %s with error: %s", platformID, err.Error())
 					}
 				}
-
+				return
+			case err := <-notifyClose:
+				if err == nil {
+					// Closed on shutdown
+					return
+				}
+				global.Logger.Errorf("MQ channel closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
+				// Start over with all platforms, unacknowledged works would be redelivered
+				for _, platformCh := range platformChannels {
+					_ = platformCh.Close()
+				}
 			}
 		}
 
@@ -101,3 +112,75 @@ func FeedCollectStartProcess() error {
 
 	return nil
 }
+
+// consumePlatform : Open channel for dispatching queue of platform, closing (with error or not) is sent to notifyClose
+func consumePlatform(platformID string, notifyClose chan<- *amqp.Error) (*amqp.Channel, <-chan amqp.Delivery, error) {
+	ch, err := commonGlobal.MQ.Channel()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chNotifyClose := ch.NotifyClose(make(chan *amqp.Error, 1))
+	go func() {
+		// Nil if closed gracefully
+		notifyClose <- <-chNotifyClose
+	}()
+
+	// Results are reported only after confirmed by broker
+	if err := ch.Confirm(false); err != nil {
+		return nil, nil, err
+	}
+
+	// Limit works in progress to what could start, unacknowledged ones would be redelivered if this worker is gone
+	if err := ch.Qos(concurrency.Prefetch(platformID, config.Config.MQPrefetch), 0, false); err != nil {
+		return nil, nil, err
+	}
+
+	deliveries, err := ch.Consume(
+		commonMQ.FeedCollectDispatchQueueName(platformID),
+		consumerTag(platformID), // To cancel on shutdown
+		false,
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ch, deliveries, nil
+}
+
+// serve : Process each delivery in background, till consumer cancelled or channel closed
+func serve(deliveries <-chan amqp.Delivery, process func(d *amqp.Delivery)) {
+	for d := range deliveries {
+		if !shutdown.TryTrack() {
+			// Draining, left unacknowledged to be requeued
+			continue
+		}
+		inFlight.Add(1)
+		d := d
+		go func() {
+			defer shutdown.Done()
+			defer inFlight.Add(-1)
+			process(&d)
+		}()
+	}
+}
+
+// adjustPrefetch : Apply current limits to channels of all platforms
+func adjustPrefetch() {
+	channelsLock.Lock()
+	defer channelsLock.Unlock()
+
+	for platformID, ch := range channels {
+		if err := ch.Qos(concurrency.Prefetch(platformID, config.Config.MQPrefetch), 0, false); err != nil {
+			global.Logger.Errorf("Failed to adjust MQ prefetch of %s with error: %s", platformID, err.Error())
+		}
+	}
+}
+
+func consumerTag(platformID string) string {
+	return fmt.Sprintf("%s:%s", config.Config.WorkerID, platformID)
+}
@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/concurrency"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeQueue : Delivers like broker does to a consumer, no more than prefetch unacknowledged
+type fakeQueue struct {
+	lock     sync.Mutex
+	cond     *sync.Cond
+	prefetch int
+	unacked  int
+	acked    int
+}
+
+func newFakeQueue(prefetch int, messages int) (*fakeQueue, <-chan amqp.Delivery) {
+	q := &fakeQueue{prefetch: prefetch}
+	q.cond = sync.NewCond(&q.lock)
+
+	deliveries := make(chan amqp.Delivery)
+	go func() {
+		defer close(deliveries)
+		for i := 0; i < messages; i++ {
+			q.lock.Lock()
+			for q.unacked >= q.prefetch {
+				q.cond.Wait()
+			}
+			q.unacked++
+			q.lock.Unlock()
+			deliveries <- amqp.Delivery{Acknowledger: q, DeliveryTag: uint64(i + 1)}
+		}
+	}()
+
+	return q, deliveries
+}
+
+func (q *fakeQueue) settle() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.unacked--
+	q.acked++
+	q.cond.Broadcast()
+	return nil
+}
+
+func (q *fakeQueue) Ack(uint64, bool) error        { return q.settle() }
+func (q *fakeQueue) Nack(uint64, bool, bool) error { return q.settle() }
+func (q *fakeQueue) Reject(uint64, bool) error     { return q.settle() }
+func (q *fakeQueue) counts() (unacked int, acked int) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.unacked, q.acked
+}
+
+func TestServeWithBusyPlatform(t *testing.T) {
+	concurrency.Init(map[commonTypes.ConcurrencyClass]int{
+		commonTypes.ConcurrencyClassDirect: 2,
+	}, nil, nil)
+
+	// Works of busy platform never finish during test
+	stuck := make(chan struct{})
+	defer close(stuck)
+	busyQueue, busyDeliveries := newFakeQueue(concurrency.Prefetch("jobs_test_busy", 100), 10)
+	go serve(busyDeliveries, func(d *amqp.Delivery) {
+		<-stuck
+		_ = d.Ack(false)
+	})
+
+	idleQueue, idleDeliveries := newFakeQueue(concurrency.Prefetch("jobs_test_idle", 100), 5)
+	go serve(idleDeliveries, func(d *amqp.Delivery) {
+		_ = d.Ack(false)
+	})
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, acked := idleQueue.counts(); acked == 5 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Works of other platform should make progress while one platform is saturated")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if unacked, acked := busyQueue.counts(); unacked != 2 || acked != 0 {
+		t.Errorf("Busy platform should take no more than it could start, got %d unacknowledged", unacked)
+	}
+}
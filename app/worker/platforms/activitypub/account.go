@@ -0,0 +1,26 @@
+package activitypub
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"strings"
+)
+
+func Account(usernameAtInstance string, validateString string) (bool, uint, string, bool) {
+
+	global.Logger.Debug("Validate string: ", validateString)
+
+	actor, errCode, err := FetchActor(usernameAtInstance, WebFingerLink)
+	if err != nil {
+		global.Logger.Errorf("Failed to fetch actor of %s with error: %s", usernameAtInstance, err.Error())
+		return false, errCode, err.Error(), false
+	}
+
+	if strings.Contains(strings.ToLower(actor.Summary), validateString) {
+		global.Logger.Debug("Account verify succeeded")
+		return true, 0, "", true
+	} else {
+		global.Logger.Debug("No validate string found: ", actor.Summary)
+		return true, 0, "", false
+	}
+
+}
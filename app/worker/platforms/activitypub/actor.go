@@ -0,0 +1,89 @@
+package activitypub
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/common/platforms/builtin"
+	commonUtils "github.com/Crossbell-Box/OperatorSync/common/utils"
+	"net/http"
+	"strings"
+)
+
+const (
+	WebFingerLink = builtin.ActivityPubWebFingerLink
+)
+
+var (
+	httpClient *http.Client // Replaceable for tests
+)
+
+func getHttpClient() *http.Client {
+	if httpClient != nil {
+		return httpClient
+	}
+
+	return httpclient.New(true)
+}
+
+// getJSON : Request with specified accept type, and parse response into res
+func getJSON(link string, accept string, res interface{}) (uint, error) {
+	req, err := http.NewRequest("GET", link, nil)
+	if err != nil {
+		return commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, err
+	}
+
+	req.Header.Set("Accept", accept)
+
+	resEntity, err := httpclient.DoWithClient(getHttpClient(), req)
+	if err != nil {
+		return httpclient.ErrorCode(err), err
+	}
+
+	if err = json.Unmarshal(resEntity.Body, res); err != nil {
+		return commonConsts.ERROR_CODE_FAILED_TO_PARSE_JSON, err
+	}
+
+	return 0, nil
+}
+
+func getActivityJSON(link string, res interface{}) (uint, error) {
+	return getJSON(link, fmt.Sprintf("%s, %s", mimeTypeActivityJson, mimeTypeLDJson), res)
+}
+
+// FetchActor : Resolve actor of username@instance through WebFinger (with link template), and fetch actor JSON
+func FetchActor(usernameAtInstance string, webFingerLink string) (*Actor, uint, error) {
+	username, instance, err := commonUtils.SplitFediverseUsernameInstance(usernameAtInstance)
+	if err != nil {
+		return nil, commonConsts.ERROR_CODE_INVALID_FORMAT, err
+	}
+
+	webFingerLink = strings.ReplaceAll(webFingerLink, "{{instance}}", instance)
+	webFingerLink = strings.ReplaceAll(webFingerLink, "{{username}}", username)
+
+	var webFinger WebFingerResponse
+	if errCode, err := getJSON(webFingerLink, "application/jrd+json, application/json", &webFinger); err != nil {
+		return nil, errCode, err
+	}
+
+	actorLink := ""
+	for _, link := range webFinger.Links {
+		if link.Rel == "self" && (link.Type == mimeTypeActivityJson || strings.HasPrefix(link.Type, "application/ld+json")) {
+			actorLink = link.Href
+			break
+		}
+	}
+	if actorLink == "" {
+		return nil, commonConsts.ERROR_CODE_FAILED_TO_FIND_NECESSARY_FIELD, fmt.Errorf("no actor link found for %s", usernameAtInstance)
+	}
+
+	var actor Actor
+	if errCode, err := getActivityJSON(actorLink, &actor); err != nil {
+		return nil, errCode, err
+	} else if actor.Error != "" {
+		return nil, commonConsts.ERROR_CODE_FAILED_TO_FIND_NECESSARY_FIELD, fmt.Errorf("failed to fetch actor: %s", actor.Error)
+	}
+
+	return &actor, 0, nil
+}
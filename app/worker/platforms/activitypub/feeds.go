@@ -0,0 +1,276 @@
+package activitypub
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"strings"
+	"time"
+)
+
+const (
+	maxOutboxPages = 5 // Prevent too many requests for a single work
+)
+
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
+	bool, []commonTypes.RawFeed, uint, string,
+) {
+	// Refer to https://docs.joinmastodon.org/spec/activitypub/
+
+	global.Logger.Debug("New feeds request for activitypub")
+
+	actor, errCode, err := FetchActor(work.Username, collectLink)
+	if err != nil {
+		return false, nil, errCode, err.Error()
+	}
+	if actor.Outbox == "" {
+		return false, nil, commonConsts.ERROR_CODE_FAILED_TO_FIND_NECESSARY_FIELD, "No outbox found"
+	}
+
+	var outbox Collection
+	if errCode, err := getActivityJSON(actor.Outbox, &outbox); err != nil {
+		return false, nil, errCode, err.Error()
+	}
+
+	var feeds []commonTypes.RawFeed
+
+	// Items might be in collection directly, or in pages
+	page := &outbox
+	if len(outbox.OrderedItems) == 0 && len(outbox.Items) == 0 {
+		page, errCode, err = getPage(outbox.First)
+		if err != nil {
+			return false, nil, errCode, err.Error()
+		}
+	}
+
+	for pageCount := 0; page != nil && pageCount < maxOutboxPages; pageCount++ {
+		isReachedDropBefore := false
+
+		items := page.OrderedItems
+		if len(items) == 0 {
+			items = page.Items
+		}
+
+		for _, rawActivity := range items {
+			var activity Activity
+			if err := json.Unmarshal(rawActivity, &activity); err != nil {
+				global.Logger.Warnf("Failed to parse activity %s with error: %s", string(rawActivity), err.Error())
+				continue
+			}
+
+			utils.ProcessingItem(work, activity.ID)
+
+			if activity.Type != "Create" {
+				// Like Announce (boost / renote), no need to post, skip this
+				continue
+			}
+
+			object, err := getObject(activity.Object)
+			if err != nil {
+				global.Logger.Warnf("Failed to get object of activity %s with error: %s", activity.ID, err.Error())
+				continue
+			}
+
+			feed, attachments := parseObject(object)
+			if feed == nil {
+				// Not public or unsupported
+				continue
+			}
+
+			if feed.PublishedAt.Before(work.DropBefore) {
+				isReachedDropBefore = true
+			}
+			if !(feed.PublishedAt.After(work.DropBefore) && feed.PublishedAt.Before(work.DropAfter)) {
+				continue
+			}
+
+			// Upload media with order
+			for _, attachment := range attachments {
+				media, err := utils.UploadOneMedia(parseLink(attachment.URL))
+				if err != nil {
+					// Fail to upload, oops
+					return false, nil, commonConsts.ERROR_CODE_FAILED_TO_UPLOAD, err.Error()
+				} else {
+					if attachment.Name != "" {
+						utils.SetMediaAdditionalProp(media, "alt", attachment.Name)
+					}
+					feed.Media = append(feed.Media, *media)
+				}
+			}
+
+			feeds = append(feeds, *feed)
+		}
+
+		if isReachedDropBefore {
+			break
+		}
+
+		page, errCode, err = getPage(page.Next)
+		if err != nil {
+			return false, nil, errCode, err.Error()
+		}
+	}
+
+	return true, feeds, 0, ""
+}
+
+// getPage : Collection page might be embedded or linked, returns nil if no more pages
+func getPage(raw json.RawMessage) (*Collection, uint, error) {
+	var page Collection
+
+	if pageLink := parseLink(raw); pageLink == "" {
+		return nil, 0, nil
+	} else if err := json.Unmarshal(raw, &page); err == nil && (len(page.OrderedItems) > 0 || len(page.Items) > 0) {
+		// Embedded
+		return &page, 0, nil
+	} else if errCode, err := getActivityJSON(pageLink, &page); err != nil {
+		return nil, errCode, err
+	}
+
+	if len(page.OrderedItems) == 0 && len(page.Items) == 0 {
+		// Empty page
+		return nil, 0, nil
+	}
+
+	return &page, 0, nil
+}
+
+// getObject : Object might be embedded or linked
+func getObject(raw json.RawMessage) (*Object, error) {
+	var object Object
+
+	if err := json.Unmarshal(raw, &object); err == nil && object.Type != "" {
+		// Embedded
+		return &object, nil
+	}
+
+	objectLink := parseLink(raw)
+	if objectLink == "" {
+		return nil, fmt.Errorf("invalid object")
+	}
+	if _, err := getActivityJSON(objectLink, &object); err != nil {
+		return nil, err
+	}
+
+	return &object, nil
+}
+
+// parseObject : Convert public object to feed, attachments are returned for uploading
+func parseObject(object *Object) (*commonTypes.RawFeed, []Attachment) {
+	switch object.Type {
+	case "Note", "Article", "Page", "Question":
+		// Supported
+	default:
+		return nil, nil
+	}
+
+	if !isPublic(object) {
+		return nil, nil
+	}
+
+	publishedAt, err := time.Parse(time.RFC3339, object.Published)
+	if err != nil {
+		return nil, nil
+	}
+
+	feed := commonTypes.RawFeed{
+		Title:       object.Name,
+		Content:     object.Content,
+		Link:        object.ID, // Same as what inReplyTo refers to
+		GUID:        object.ID,
+		PublishedAt: publishedAt,
+	}
+
+	feed.ForURI = parseLink(object.InReplyTo)
+
+	// Content warning: summary is the warning text (like Mastodon CW), sensitive marks media
+	if object.Summary != "" {
+		feed.ContentWarning = "spoiler"
+		if feed.Title == "" {
+			feed.Title = object.Summary
+		}
+	} else if object.Sensitive {
+		feed.ContentWarning = "sensitive"
+	}
+
+	for _, rawTag := range parseList(object.Tag) {
+		var tag Tag
+		if err := json.Unmarshal(rawTag, &tag); err == nil && tag.Type == "Hashtag" {
+			feed.Categories = append(feed.Categories, strings.TrimPrefix(tag.Name, "#"))
+		}
+	}
+
+	var attachments []Attachment
+	for _, rawAttachment := range parseList(object.Attachment) {
+		var attachment Attachment
+		if err := json.Unmarshal(rawAttachment, &attachment); err == nil && parseLink(attachment.URL) != "" {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	return &feed, attachments
+}
+
+func isPublic(object *Object) bool {
+	for _, address := range append(parseStrings(object.To), parseStrings(object.CC)...) {
+		if address == publicAddress || address == "as:Public" || address == "Public" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseList : Single value or array
+func parseList(raw json.RawMessage) []json.RawMessage {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+
+	return []json.RawMessage{raw}
+}
+
+func parseStrings(raw json.RawMessage) []string {
+	var values []string
+	for _, item := range parseList(raw) {
+		var value string
+		if err := json.Unmarshal(item, &value); err == nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseLink : Link might be string, Link / Object (with href or id) or array of them
+func parseLink(raw json.RawMessage) string {
+	for _, item := range parseList(raw) {
+		var link string
+		if err := json.Unmarshal(item, &link); err == nil {
+			if link != "" {
+				return link
+			}
+			continue
+		}
+
+		var linkObject struct {
+			Href string `json:"href"`
+			ID   string `json:"id"`
+		}
+		if err := json.Unmarshal(item, &linkObject); err == nil {
+			if linkObject.Href != "" {
+				return linkObject.Href
+			} else if linkObject.ID != "" {
+				return linkObject.ID
+			}
+		}
+	}
+
+	return ""
+}
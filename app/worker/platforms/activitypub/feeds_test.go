@@ -0,0 +1,87 @@
+package activitypub
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeeds(t *testing.T) {
+	// Init deps
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync() // Unable to handle errors here
+	global.Logger = logger.Sugar()
+
+	// Local stand-in instance
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := ""
+		switch r.URL.Path {
+		case "/.well-known/webfinger":
+			body = `{"subject": "acct:alice@{{host}}", "links": [{"rel": "self", "type": "application/activity+json", "href": "{{base}}/users/alice"}]}`
+		case "/users/alice":
+			body = `{"id": "{{base}}/users/alice", "type": "Person", "summary": "<p>alice@Crossbell</p>", "outbox": "{{base}}/users/alice/outbox"}`
+		case "/users/alice/outbox":
+			if r.URL.Query().Get("page") == "" {
+				body = `{"type": "OrderedCollection", "first": "{{base}}/users/alice/outbox?page=1"}`
+			} else {
+				body = `{"type": "OrderedCollectionPage", "next": "{{base}}/users/alice/outbox?page=2", "orderedItems": [
+  {"type": "Announce", "object": "https://elsewhere.example/notes/1"},
+  {"type": "Create", "object": {
+    "id": "{{base}}/users/alice/statuses/2", "type": "Note", "published": "2023-05-01T08:00:00Z",
+    "content": "<p>Reply</p>", "summary": "Spoilers", "sensitive": true,
+    "inReplyTo": "{{base}}/users/alice/statuses/1",
+    "to": ["https://www.w3.org/ns/activitystreams#Public"],
+    "tag": [{"type": "Hashtag", "name": "#test"}]
+  }},
+  {"type": "Create", "object": {
+    "id": "{{base}}/users/alice/statuses/followers-only", "type": "Note", "published": "2023-05-01T07:00:00Z",
+    "content": "<p>Followers only</p>", "to": ["{{base}}/users/alice/followers"]
+  }},
+  {"type": "Create", "object": {
+    "id": "{{base}}/users/alice/statuses/0", "type": "Note", "published": "2023-04-01T07:00:00Z",
+    "content": "<p>Too old</p>", "cc": ["https://www.w3.org/ns/activitystreams#Public"]
+  }}
+]}`
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body = strings.ReplaceAll(body, "{{base}}", server.URL)
+		body = strings.ReplaceAll(body, "{{host}}", server.Listener.Addr().String())
+		_, _ = fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+	httpClient = server.Client()
+	defer func() { httpClient = nil }()
+
+	usernameAtInstance := "alice@" + server.Listener.Addr().String()
+
+	isSucceeded, feeds, errCode, errMsg := Feeds(&commonTypes.WorkDispatched{
+		Username:   usernameAtInstance,
+		DropBefore: time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC),
+		DropAfter:  time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC),
+	}, WebFingerLink)
+	if !isSucceeded {
+		t.Fatal(errCode, errMsg)
+	}
+	if len(feeds) != 1 {
+		t.Fatalf("expected 1 feed, got %v", feeds)
+	}
+	if feeds[0].ForURI != server.URL+"/users/alice/statuses/1" ||
+		feeds[0].ContentWarning != "spoiler" ||
+		len(feeds[0].Categories) != 1 || feeds[0].Categories[0] != "test" {
+		t.Errorf("unexpected feed: %v", feeds[0])
+	}
+
+	if _, _, _, isValid := Account(usernameAtInstance, "alice@crossbell"); !isValid {
+		t.Error("validate string not found in actor summary")
+	}
+}
@@ -0,0 +1,13 @@
+package activitypub
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
+	_ "github.com/Crossbell-Box/OperatorSync/common/platforms/builtin" // Meta of platform
+)
+
+func init() {
+	platforms.Register("activitypub", &platforms.Funcs{
+		AccountFunc: Account,
+		FeedsFunc:   Feeds,
+	})
+}
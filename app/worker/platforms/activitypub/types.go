@@ -0,0 +1,76 @@
+package activitypub
+
+import "encoding/json"
+
+// Only fields we need, refer to https://www.w3.org/TR/activitystreams-vocabulary/
+
+const (
+	publicAddress = "https://www.w3.org/ns/activitystreams#Public"
+
+	mimeTypeActivityJson = "application/activity+json"
+	mimeTypeLDJson       = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+)
+
+type WebFingerResponse struct {
+	Subject string `json:"subject"`
+	Links   []struct {
+		Rel  string `json:"rel"`
+		Type string `json:"type"`
+		Href string `json:"href"`
+	} `json:"links"`
+}
+
+type Actor struct {
+	ID                string `json:"id"`
+	Type              string `json:"type"`
+	PreferredUsername string `json:"preferredUsername"`
+	Summary           string `json:"summary"`
+	Outbox            string `json:"outbox"`
+
+	// Or error
+	Error string `json:"error"`
+}
+
+// Collection : OrderedCollection or OrderedCollectionPage
+type Collection struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	First        json.RawMessage   `json:"first"` // Link or page object
+	Next         json.RawMessage   `json:"next"`
+	OrderedItems []json.RawMessage `json:"orderedItems"`
+	Items        []json.RawMessage `json:"items"` // Some implementations use unordered one
+}
+
+type Activity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"` // Link or object
+}
+
+type Object struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	URL        json.RawMessage `json:"url"` // Link, object or array of them
+	Name       string          `json:"name"`
+	Summary    string          `json:"summary"`
+	Content    string          `json:"content"`
+	Sensitive  bool            `json:"sensitive"`
+	InReplyTo  json.RawMessage `json:"inReplyTo"`
+	Published  string          `json:"published"`
+	To         json.RawMessage `json:"to"`
+	CC         json.RawMessage `json:"cc"`
+	Attachment json.RawMessage `json:"attachment"` // Object or array of them
+	Tag        json.RawMessage `json:"tag"`
+}
+
+type Attachment struct {
+	Type      string          `json:"type"`
+	MediaType string          `json:"mediaType"`
+	URL       json.RawMessage `json:"url"`
+	Name      string          `json:"name"` // Alt text
+}
+
+type Tag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
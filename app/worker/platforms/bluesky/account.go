@@ -0,0 +1,31 @@
+package bluesky
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"net/url"
+	"strings"
+)
+
+func Account(username string, validateString string) (bool, uint, string, bool) {
+
+	global.Logger.Debug("Validate string: ", validateString)
+
+	var profile ProfileResponse
+	if errCode, err := xrpcGet(
+		replaceEndpoint(fmt.Sprintf("{{bluesky_pds}}/xrpc/app.bsky.actor.getProfile?actor=%s", url.QueryEscape(username))),
+		&profile,
+	); err != nil {
+		global.Logger.Error("Failed to get bluesky profile of user ", username, " for account validate with error: ", err.Error())
+		return false, errCode, err.Error(), false
+	}
+
+	if strings.Contains(strings.ToLower(profile.Description), validateString) {
+		global.Logger.Debug("Account verify succeeded")
+		return true, 0, "", true
+	} else {
+		global.Logger.Debug("No validate string found: ", profile.Description)
+		return true, 0, "", false
+	}
+
+}
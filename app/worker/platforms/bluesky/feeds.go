@@ -0,0 +1,177 @@
+package bluesky
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	maxFeedPages = 5 // Prevent too many requests for a single work
+)
+
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
+	bool, []commonTypes.RawFeed, uint, string,
+) {
+	// Refer to https://public.api.bsky.app/xrpc/app.bsky.feed.getAuthorFeed?actor=bsky.app
+
+	global.Logger.Debug("New feeds request for bluesky")
+
+	collectLink = replaceEndpoint(strings.ReplaceAll(collectLink, "{{username}}", url.QueryEscape(work.Username)))
+
+	var feeds []commonTypes.RawFeed
+
+	cursor := ""
+	for page := 0; page < maxFeedPages; page++ {
+		pageLink := collectLink
+		if cursor != "" {
+			pageLink = fmt.Sprintf("%s&cursor=%s", collectLink, url.QueryEscape(cursor))
+		}
+
+		var res AuthorFeedResponse
+		if errCode, err := xrpcGet(pageLink, &res); err != nil {
+			return false, nil, errCode, err.Error()
+		}
+
+		isReachedDropBefore := false
+
+		for _, item := range res.Feed {
+			utils.ProcessingItem(work, item.Post.URI)
+
+			if item.Reason != nil {
+				if item.Reason.Type == typeReasonRepost {
+					// No need to post, skip this
+					continue
+				}
+				// Like pinned post, might be out of order
+			} else if item.Post.publishedAt().Before(work.DropBefore) {
+				isReachedDropBefore = true
+			}
+
+			feed, images := parsePost(&item.Post)
+			if !(feed.PublishedAt.After(work.DropBefore) && feed.PublishedAt.Before(work.DropAfter)) {
+				continue
+			}
+
+			// Upload media with order
+			for _, image := range images {
+				media, err := utils.UploadOneMedia(image.Fullsize)
+				if err != nil {
+					// Fail to upload, oops
+					return false, nil, commonConsts.ERROR_CODE_FAILED_TO_UPLOAD, err.Error()
+				} else {
+					if image.Alt != "" {
+						utils.SetMediaAdditionalProp(media, "alt", image.Alt)
+					}
+					feed.Media = append(feed.Media, *media)
+				}
+			}
+
+			feeds = append(feeds, *feed)
+		}
+
+		if isReachedDropBefore || res.Cursor == "" {
+			break
+		}
+		cursor = res.Cursor
+	}
+
+	return true, feeds, 0, ""
+
+}
+
+func (p *PostView) publishedAt() time.Time {
+	if createdAt, err := time.Parse(time.RFC3339Nano, p.Record.CreatedAt); err == nil {
+		return createdAt
+	}
+	// Record created time is provided by client, might be invalid
+	indexedAt, _ := time.Parse(time.RFC3339Nano, p.IndexedAt)
+	return indexedAt
+}
+
+// parsePost : Convert post to feed, images are returned for uploading
+func parsePost(post *PostView) (*commonTypes.RawFeed, []ImageView) {
+	feed := commonTypes.RawFeed{
+		Link:        postWebLink(post.URI),
+		GUID:        post.URI,
+		PublishedAt: post.publishedAt(),
+		Content:     applyFacets(post.Record.Text, post.Record.Facets),
+	}
+
+	if len(post.Record.Langs) > 0 {
+		feed.Language = post.Record.Langs[0]
+	}
+
+	// Relations: reply first, then quote
+	if post.Record.Reply != nil {
+		feed.ForURI = postWebLink(post.Record.Reply.Parent.URI)
+	}
+	if feed.ForURI == "" && post.Record.Embed != nil && post.Record.Embed.Record != nil {
+		switch post.Record.Embed.Type {
+		case typeEmbedRecord:
+			feed.ForURI = postWebLink(post.Record.Embed.Record.URI)
+		case typeEmbedRecordWithMedia:
+			if post.Record.Embed.Record.Record != nil {
+				feed.ForURI = postWebLink(post.Record.Embed.Record.Record.URI)
+			}
+		}
+	}
+
+	var images []ImageView
+	if post.Embed != nil {
+		switch post.Embed.Type {
+		case typeEmbedImagesView:
+			images = post.Embed.Images
+		case typeEmbedRecordWithMediaView:
+			if post.Embed.Media != nil && post.Embed.Media.Type == typeEmbedImagesView {
+				images = post.Embed.Media.Images
+			}
+		}
+	}
+
+	return &feed, images
+}
+
+// applyFacets : Convert links and mentions into markdown, so that shortened links are expanded
+func applyFacets(text string, facets []Facet) string {
+	sort.Slice(facets, func(i, j int) bool {
+		return facets[i].Index.ByteStart < facets[j].Index.ByteStart
+	})
+
+	var sb strings.Builder
+	lastEnd := 0
+	for _, facet := range facets {
+		start, end := facet.Index.ByteStart, facet.Index.ByteEnd
+		if start < lastEnd || end <= start || end > len(text) {
+			// Invalid or overlapped
+			continue
+		}
+
+		target := ""
+		for _, feature := range facet.Features {
+			switch feature.Type {
+			case typeFacetLink:
+				target = feature.URI
+			case typeFacetMention:
+				target = fmt.Sprintf("https://bsky.app/profile/%s", feature.DID)
+			}
+		}
+		if target == "" {
+			// Like tags, keep as is
+			continue
+		}
+
+		sb.WriteString(text[lastEnd:start])
+		sb.WriteString(fmt.Sprintf("[%s](%s)", text[start:end], target))
+		lastEnd = end
+	}
+	sb.WriteString(text[lastEnd:])
+
+	return sb.String()
+}
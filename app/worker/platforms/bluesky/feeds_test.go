@@ -0,0 +1,116 @@
+package bluesky
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testAuthorFeed = `{
+  "feed": [
+    {
+      "post": {
+        "uri": "at://did:plc:alice/app.bsky.feed.post/3repost",
+        "record": {"text": "Someone else's post", "createdAt": "2023-05-01T09:00:00.000Z"},
+        "indexedAt": "2023-05-01T09:00:00.000Z"
+      },
+      "reason": {"$type": "app.bsky.feed.defs#reasonRepost"}
+    },
+    {
+      "post": {
+        "uri": "at://did:plc:alice/app.bsky.feed.post/3reply",
+        "record": {
+          "text": "Agreed, see example.com/long...",
+          "createdAt": "2023-05-01T08:00:00.000Z",
+          "langs": ["en"],
+          "facets": [{"index": {"byteStart": 12, "byteEnd": 31}, "features": [{"$type": "app.bsky.richtext.facet#link", "uri": "https://example.com/long/path"}]}],
+          "reply": {
+            "root": {"uri": "at://did:plc:bob/app.bsky.feed.post/3root", "cid": "x"},
+            "parent": {"uri": "at://did:plc:bob/app.bsky.feed.post/3parent", "cid": "x"}
+          }
+        },
+        "indexedAt": "2023-05-01T08:00:00.000Z"
+      }
+    },
+    {
+      "post": {
+        "uri": "at://did:plc:alice/app.bsky.feed.post/3quote",
+        "record": {
+          "text": "Look at this",
+          "createdAt": "2023-05-01T07:00:00.000Z",
+          "embed": {"$type": "app.bsky.embed.record", "record": {"uri": "at://did:plc:bob/app.bsky.feed.post/3quoted", "cid": "x"}}
+        },
+        "indexedAt": "2023-05-01T07:00:00.000Z"
+      }
+    },
+    {
+      "post": {
+        "uri": "at://did:plc:alice/app.bsky.feed.post/3old",
+        "record": {"text": "Too old", "createdAt": "2023-04-01T07:00:00.000Z"},
+        "indexedAt": "2023-04-01T07:00:00.000Z"
+      }
+    }
+  ],
+  "cursor": "should-not-be-requested"
+}`
+
+func TestFeeds(t *testing.T) {
+	// Init deps
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync() // Unable to handle errors here
+	global.Logger = logger.Sugar()
+
+	// Local stand-in PDS
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/xrpc/app.bsky.feed.getAuthorFeed":
+			_, _ = fmt.Fprint(w, testAuthorFeed)
+		case "/xrpc/app.bsky.actor.getProfile":
+			_, _ = fmt.Fprint(w, `{"did": "did:plc:alice", "handle": "alice.test", "description": "Hi! alice@Crossbell"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"error": "MethodNotImplemented", "message": "Method Not Implemented"}`)
+		}
+	}))
+	defer server.Close()
+	httpclient.TrustHost(server.URL)
+	config.Config.BlueskyPDSEndpoint = server.URL
+
+	platformMeta, _ := commonPlatforms.Meta("bluesky")
+	isSucceeded, feeds, errCode, errMsg := Feeds(&commonTypes.WorkDispatched{
+		Username:   "alice.test",
+		DropBefore: time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC),
+		DropAfter:  time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC),
+	}, platformMeta.FeedLink)
+	if !isSucceeded {
+		t.Fatal(errCode, errMsg)
+	}
+	if requests != 1 {
+		t.Errorf("should stop paging after reaching drop before, got %d requests", requests)
+	}
+	if len(feeds) != 2 {
+		t.Fatalf("expected 2 feeds, got %v", feeds)
+	}
+	if feeds[0].ForURI != "https://bsky.app/profile/did:plc:bob/post/3parent" ||
+		feeds[0].Content != "Agreed, see [example.com/long...](https://example.com/long/path)" ||
+		feeds[0].Link != "https://bsky.app/profile/did:plc:alice/post/3reply" {
+		t.Errorf("unexpected reply feed: %v", feeds[0])
+	}
+	if feeds[1].ForURI != "https://bsky.app/profile/did:plc:bob/post/3quoted" {
+		t.Errorf("unexpected quote feed: %v", feeds[1])
+	}
+
+	if _, _, _, isValid := Account("alice.test", "alice@crossbell"); !isValid {
+		t.Error("validate string not found in profile")
+	}
+}
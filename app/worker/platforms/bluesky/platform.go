@@ -0,0 +1,13 @@
+package bluesky
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
+	_ "github.com/Crossbell-Box/OperatorSync/common/platforms/builtin" // Meta of platform
+)
+
+func init() {
+	platforms.Register("bluesky", &platforms.Funcs{
+		AccountFunc: Account,
+		FeedsFunc:   Feeds,
+	})
+}
@@ -0,0 +1,102 @@
+package bluesky
+
+// Only fields we need, refer to https://github.com/bluesky-social/atproto/tree/main/lexicons
+
+const (
+	collectionPost = "app.bsky.feed.post"
+
+	typeReasonRepost             = "app.bsky.feed.defs#reasonRepost"
+	typeEmbedRecord              = "app.bsky.embed.record"
+	typeEmbedRecordWithMedia     = "app.bsky.embed.recordWithMedia"
+	typeEmbedImagesView          = "app.bsky.embed.images#view"
+	typeEmbedRecordWithMediaView = "app.bsky.embed.recordWithMedia#view"
+	typeFacetLink                = "app.bsky.richtext.facet#link"
+	typeFacetMention             = "app.bsky.richtext.facet#mention"
+)
+
+type XRPCError struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type ProfileResponse struct {
+	DID         string `json:"did"`
+	Handle      string `json:"handle"`
+	Description string `json:"description"`
+
+	XRPCError
+}
+
+type AuthorFeedResponse struct {
+	Cursor string         `json:"cursor"`
+	Feed   []FeedViewPost `json:"feed"`
+
+	XRPCError
+}
+
+type FeedViewPost struct {
+	Post   PostView `json:"post"`
+	Reason *struct {
+		Type string `json:"$type"`
+	} `json:"reason"`
+}
+
+type PostView struct {
+	URI       string     `json:"uri"`
+	Record    PostRecord `json:"record"`
+	Embed     *EmbedView `json:"embed"`
+	IndexedAt string     `json:"indexedAt"`
+}
+
+type PostRecord struct {
+	Text      string       `json:"text"`
+	CreatedAt string       `json:"createdAt"`
+	Langs     []string     `json:"langs"`
+	Facets    []Facet      `json:"facets"`
+	Reply     *ReplyRef    `json:"reply"`
+	Embed     *RecordEmbed `json:"embed"`
+}
+
+type StrongRef struct {
+	URI string `json:"uri"`
+	CID string `json:"cid"`
+}
+
+type ReplyRef struct {
+	Root   StrongRef `json:"root"`
+	Parent StrongRef `json:"parent"`
+}
+
+type RecordEmbed struct {
+	Type string `json:"$type"`
+
+	// app.bsky.embed.record : StrongRef
+	// app.bsky.embed.recordWithMedia : { record: StrongRef }
+	Record *struct {
+		StrongRef
+		Record *StrongRef `json:"record"`
+	} `json:"record"`
+}
+
+type EmbedView struct {
+	Type   string      `json:"$type"`
+	Images []ImageView `json:"images"` // app.bsky.embed.images#view
+	Media  *EmbedView  `json:"media"`  // app.bsky.embed.recordWithMedia#view
+}
+
+type ImageView struct {
+	Fullsize string `json:"fullsize"`
+	Alt      string `json:"alt"`
+}
+
+type Facet struct {
+	Index struct {
+		ByteStart int `json:"byteStart"`
+		ByteEnd   int `json:"byteEnd"`
+	} `json:"index"`
+	Features []struct {
+		Type string `json:"$type"`
+		URI  string `json:"uri"` // Link
+		DID  string `json:"did"` // Mention
+	} `json:"features"`
+}
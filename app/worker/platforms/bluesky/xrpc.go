@@ -0,0 +1,45 @@
+package bluesky
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"strings"
+)
+
+func replaceEndpoint(link string) string {
+	return strings.ReplaceAll(link, "{{bluesky_pds}}", config.Config.BlueskyPDSEndpoint)
+}
+
+// xrpcGet : Request XRPC query and parse response into res
+func xrpcGet(link string, res interface{}) (uint, error) {
+	body, err := utils.HttpRequest(link, true)
+	if err != nil {
+		return httpclient.ErrorCode(err), err
+	}
+
+	var xrpcErr XRPCError
+	if err = json.Unmarshal(body, &xrpcErr); err != nil {
+		return commonConsts.ERROR_CODE_FAILED_TO_PARSE_JSON, err
+	} else if xrpcErr.Error != "" {
+		return commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, fmt.Errorf("xrpc error %s: %s", xrpcErr.Error, xrpcErr.Message)
+	}
+
+	if err = json.Unmarshal(body, res); err != nil {
+		return commonConsts.ERROR_CODE_FAILED_TO_PARSE_JSON, err
+	}
+
+	return 0, nil
+}
+
+// postWebLink : at://<did>/app.bsky.feed.post/<rkey> -> https://bsky.app/profile/<did>/post/<rkey>
+func postWebLink(atUri string) string {
+	parts := strings.Split(strings.TrimPrefix(atUri, "at://"), "/")
+	if !strings.HasPrefix(atUri, "at://") || len(parts) != 3 || parts[1] != collectionPost {
+		return ""
+	}
+	return fmt.Sprintf("https://bsky.app/profile/%s/post/%s", parts[0], parts[2])
+}
@@ -0,0 +1,21 @@
+// Package builtin registers collectors of all built-in platforms (with their meta), for worker only.
+// Private platforms can be registered the same way with their own blank imports.
+package builtin
+
+import (
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/activitypub"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/bluesky"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/custom_feed"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/jike"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/mastodon"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/medium"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/nostr"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/pinterest"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/pixiv"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/podcast"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/substack"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/tg_channel"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/tiktok"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/twitter"
+	_ "github.com/Crossbell-Box/OperatorSync/app/worker/platforms/y2b_channel"
+)
@@ -0,0 +1,77 @@
+package platforms
+
+import (
+	"fmt"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"sort"
+	"sync"
+)
+
+// Collector : Worker side of a platform, its meta is registered in common platforms
+type Collector interface {
+	// Account : Check if validate string exists, returns (isSucceeded, errCode, errMsg, isValid)
+	Account(username string, validateString string) (bool, uint, string, bool)
+
+	// Feeds : Collect feeds for dispatched work, returns (isSucceeded, feeds, errCode, errMsg)
+	Feeds(work *commonTypes.WorkDispatched, collectLink string) (bool, []commonTypes.RawFeed, uint, string)
+}
+
+// Funcs : Collector with plain functions, so most platforms don't need their own type
+type Funcs struct {
+	AccountFunc func(username string, validateString string) (bool, uint, string, bool)
+	FeedsFunc   func(work *commonTypes.WorkDispatched, collectLink string) (bool, []commonTypes.RawFeed, uint, string)
+}
+
+func (f *Funcs) Account(username string, validateString string) (bool, uint, string, bool) {
+	return f.AccountFunc(username, validateString)
+}
+
+func (f *Funcs) Feeds(work *commonTypes.WorkDispatched, collectLink string) (bool, []commonTypes.RawFeed, uint, string) {
+	return f.FeedsFunc(work, collectLink)
+}
+
+var (
+	collectorsLock sync.RWMutex
+	collectors     = make(map[string]Collector)
+)
+
+// Register : Add collector of a platform, should be called in platform package's init() after its meta registered
+func Register(platformID string, c Collector) {
+	collectorsLock.Lock()
+	defer collectorsLock.Unlock()
+
+	if c == nil {
+		panic("platforms: Register collector is nil")
+	}
+	if !commonPlatforms.IsSupported(platformID) {
+		panic(fmt.Sprintf("platforms: Register called for platform %s without meta", platformID))
+	}
+	if _, dup := collectors[platformID]; dup {
+		panic(fmt.Sprintf("platforms: Register called twice for platform %s", platformID))
+	}
+
+	collectors[platformID] = c
+}
+
+func Get(platformID string) (Collector, bool) {
+	collectorsLock.RLock()
+	defer collectorsLock.RUnlock()
+
+	c, ok := collectors[platformID]
+	return c, ok
+}
+
+// IDs : Platforms this worker is able to collect, sorted
+func IDs() []string {
+	collectorsLock.RLock()
+	defer collectorsLock.RUnlock()
+
+	ids := make([]string, 0, len(collectors))
+	for id := range collectors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
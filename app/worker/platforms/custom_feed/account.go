@@ -0,0 +1,93 @@
+package custom_feed
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	linkTagRegex *regexp.Regexp
+	metaTagRegex *regexp.Regexp
+	relMeRegex   *regexp.Regexp
+)
+
+func init() {
+	linkTagRegex = regexp.MustCompile(`<link\b[^>]*>`)
+	metaTagRegex = regexp.MustCompile(`<meta\b[^>]*>`)
+	relMeRegex = regexp.MustCompile(`\brel=["']?([^"'>]*\s)?me(\s[^"'>]*)?["'\s/>]`)
+}
+
+func Account(username string, validateString string) (bool, uint, string, bool) {
+
+	global.Logger.Debug("Validate string: ", validateString)
+
+	feedLink, err := utils.ParseHttpUri(username) // Username is feed URL
+	if err != nil {
+		return false, commonConsts.ERROR_CODE_INVALID_FORMAT, err.Error(), false
+	}
+
+	// Check feed description first
+	rawFeed, errCode, err := utils.RSSFeedRequest(feedLink, true)
+	if err != nil {
+		global.Logger.Error("Failed to request feed ", feedLink, " for account validate with error: ", err.Error())
+		return false, errCode, err.Error(), false
+	}
+
+	if strings.Contains(strings.ToLower(rawFeed.Description), validateString) {
+		global.Logger.Debug("Account verify succeeded")
+		return true, 0, "", true
+	}
+
+	// Then site home page
+	if rawFeed.Link == "" {
+		global.Logger.Debug("No validate string found in feed description and no home page link: ", rawFeed.Description)
+		return true, 0, "", false
+	}
+
+	homeLink, err := url.Parse(feedLink)
+	if err == nil {
+		homeLink, err = homeLink.Parse(rawFeed.Link) // Might be relative
+	}
+	if err != nil {
+		global.Logger.Error("Failed to parse home page link ", rawFeed.Link, " of feed ", feedLink)
+		return false, commonConsts.ERROR_CODE_INVALID_FORMAT, err.Error(), false
+	}
+
+	pageContent, err := utils.HttpRequest(homeLink.String(), true)
+	if err != nil {
+		global.Logger.Error("Failed to check home page ", homeLink.String(), " for account validate with error: ", err.Error())
+		return false, httpclient.ErrorCode(err), err.Error(), false
+	}
+
+	if isValidateStringInPage(string(pageContent), validateString) {
+		global.Logger.Debug("Account verify succeeded")
+		return true, 0, "", true
+	} else {
+		global.Logger.Debug("No validate string found in feed description or home page of ", feedLink)
+		return true, 0, "", false
+	}
+
+}
+
+// isValidateStringInPage : Find validate string in <link rel="me"> or <meta> tags
+func isValidateStringInPage(pageContent string, validateString string) bool {
+	for _, tag := range linkTagRegex.FindAllString(pageContent, -1) {
+		tag = strings.ToLower(tag)
+		if relMeRegex.MatchString(tag) && strings.Contains(tag, validateString) {
+			return true
+		}
+	}
+
+	for _, tag := range metaTagRegex.FindAllString(pageContent, -1) {
+		if strings.Contains(strings.ToLower(tag), validateString) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,21 @@
+package custom_feed
+
+import "testing"
+
+func TestIsValidateStringInPage(t *testing.T) {
+	page := `<html><head>
+<link rel="stylesheet" href="/style.css?by=someone@crossbell">
+<link href="https://crossbell.io/@someone" rel="me">
+<meta name="fediverse:creator" content="someone@crossbell">
+</head></html>`
+
+	if !isValidateStringInPage(page, "someone@crossbell") {
+		t.Fatal("validate string in meta tag not found")
+	}
+	if isValidateStringInPage(`<link rel="stylesheet" href="/style.css?by=someone@crossbell">`, "someone@crossbell") {
+		t.Fatal("validate string should only be found in rel=me links")
+	}
+	if !isValidateStringInPage(`<link rel="me authn" href="mailto:someone@crossbell">`, "someone@crossbell") {
+		t.Fatal("validate string in rel=me link not found")
+	}
+}
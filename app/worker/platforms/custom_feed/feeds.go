@@ -0,0 +1,159 @@
+package custom_feed
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	imageRegex *regexp.Regexp
+)
+
+func init() {
+	imageRegex = regexp.MustCompile(`<img[^>]+\bsrc=["']([^"']+)["']`)
+}
+
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
+	bool, []commonTypes.RawFeed, uint, string,
+) {
+	// Any RSS / Atom / JSON Feed, like https://blog.example.com/feed.xml
+
+	global.Logger.Debug("New feeds request for custom feed")
+
+	feedLink, err := utils.ParseHttpUri(strings.ReplaceAll(collectLink, "{{username}}", work.Username))
+	if err != nil {
+		return false, nil, commonConsts.ERROR_CODE_INVALID_FORMAT, err.Error()
+	}
+
+	// Format is detected by parser, JSON Feed included
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(work, feedLink, true)
+	if err != nil {
+		return false, nil, errCode, err.Error()
+	}
+
+	baseUri, _ := url.Parse(feedLink) // Already validated
+
+	// Fetch deeper if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, pagedFetcher(baseUri))
+
+	var feeds []commonTypes.RawFeed
+
+	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		// Personal sites might only provide updated time
+		publishedAt := item.PublishedParsed
+		if publishedAt == nil {
+			publishedAt = item.UpdatedParsed
+		}
+		if publishedAt == nil {
+			continue
+		}
+
+		if publishedAt.After(work.DropBefore) && publishedAt.Before(work.DropAfter) {
+			feed := commonTypes.RawFeed{
+				Title:       item.Title,
+				Description: item.Description,
+				Link:        resolveUri(baseUri, item.Link),
+				GUID:        item.GUID,
+				Categories:  item.Categories,
+				Authors:     utils.ParseAuthors(item.Authors),
+				PublishedAt: *publishedAt,
+			}
+			if item.UpdatedParsed != nil {
+				feed.UpdatedAt = *item.UpdatedParsed
+			}
+
+			// Cover image
+			if item.Image != nil && item.Image.URL != "" {
+				if uploadedImg := utils.UploadAllMedia([]string{resolveUri(baseUri, item.Image.URL)}); len(uploadedImg) > 0 {
+					feed.Image = uploadedImg[0].IPFSUri
+				}
+			}
+			if feed.Image == "" {
+				for _, e := range item.Enclosures {
+					if strings.HasPrefix(e.Type, "image/") {
+						if uploadedImg := utils.UploadAllMedia([]string{resolveUri(baseUri, e.URL)}); len(uploadedImg) > 0 {
+							feed.Image = uploadedImg[0].IPFSUri
+							break
+						}
+					}
+				}
+			}
+
+			// Process content
+			rawContent := item.Content
+			if rawContent == "" {
+				// Summary only feeds
+				rawContent = item.Description
+			}
+
+			// Images might use relative links
+			imgs := imageRegex.FindAllStringSubmatch(rawContent, -1)
+			var images []string
+			originalImages := make(map[string][]string) // Uploaded (unescaped) URI -> src in content
+			for _, img := range imgs {
+				resolved := resolveUri(baseUri, img[1])
+				images = append(images, resolved)
+				unescaped := html.UnescapeString(resolved)
+				originalImages[unescaped] = append(originalImages[unescaped], img[1])
+			}
+
+			feed.Media = utils.UploadAllMedia(images)
+			for _, media := range feed.Media {
+				for _, original := range originalImages[media.OriginalURI] {
+					rawContent = strings.ReplaceAll(rawContent, original, media.IPFSUri)
+				}
+			}
+
+			feed.Content = rawContent
+
+			feeds = append(feeds, feed)
+
+		}
+	}
+
+	return true, feeds, 0, ""
+
+}
+
+// pagedFetcher : WordPress feeds paginate with ?paged=N, other sites mostly ignore it and return the same items
+func pagedFetcher(feedUri *url.URL) utils.FeedPageFetcher {
+	return func(page int, _ *gofeed.Item) ([]*gofeed.Item, bool, error) {
+		pageUri := *feedUri
+		query := pageUri.Query()
+		query.Set("paged", strconv.Itoa(page))
+		pageUri.RawQuery = query.Encode()
+
+		rawFeed, _, err := utils.RSSFeedRequest(pageUri.String(), true)
+		if httpclient.IsStatus(err, http.StatusNotFound) {
+			// No more pages
+			return nil, true, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+
+		return rawFeed.Items, len(rawFeed.Items) == 0, nil
+	}
+}
+
+func resolveUri(base *url.URL, rawUri string) string {
+	if rawUri == "" {
+		return ""
+	}
+	uri, err := base.Parse(rawUri)
+	if err != nil {
+		return rawUri
+	}
+	return uri.String()
+}
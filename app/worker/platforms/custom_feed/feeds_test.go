@@ -0,0 +1,88 @@
+package custom_feed
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestFeedsPrivateAddress(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	global.Logger = logger.Sugar()
+	config.Config.HttpRetries = 0
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Internal</title></channel></rss>`))
+	}))
+	defer server.Close()
+
+	for _, link := range []string{
+		server.URL + "/feed.xml",
+		strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/feed.xml", // Resolved before checked
+	} {
+		isSucceeded, _, errCode, errMsg := Feeds(&commonTypes.WorkDispatched{Username: link}, "{{username}}")
+		if isSucceeded || errCode != commonConsts.ERROR_CODE_INVALID_FORMAT {
+			t.Errorf("fetching %s should be rejected, got %d %s", link, errCode, errMsg)
+		}
+
+		isSucceeded, errCode, errMsg, _ = Account(link, "someone@crossbell")
+		if isSucceeded || errCode != commonConsts.ERROR_CODE_INVALID_FORMAT {
+			t.Errorf("validating %s should be rejected, got %d %s", link, errCode, errMsg)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("should never reach private address, got %d requests", requests)
+	}
+}
+
+func TestFeedsPrivateAddressWithProxy(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	global.Logger = logger.Sugar()
+	config.Config.HttpRetries = 0
+
+	// Proxy is our own service, but what it's asked to fetch is not
+	proxied := 0
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied++
+		_, _ = w.Write([]byte(`<rss version="2.0"><channel><title>Internal</title></channel></rss>`))
+	}))
+	defer proxy.Close()
+	httpclient.TrustHost(proxy.URL)
+
+	originalProxyURL := config.Config.ProxyURL
+	t.Cleanup(func() {
+		config.Config.ProxyURL = originalProxyURL
+	})
+	config.Config.ProxyURL, _ = url.Parse(proxy.URL)
+
+	for _, link := range []string{
+		"http://127.0.0.1:8080/feed.xml",
+		"http://localhost/feed.xml",
+		"http://169.254.169.254/latest/meta-data/",
+	} {
+		isSucceeded, _, errCode, errMsg := Feeds(&commonTypes.WorkDispatched{Username: link}, "{{username}}")
+		if isSucceeded || errCode != commonConsts.ERROR_CODE_INVALID_FORMAT {
+			t.Errorf("fetching %s through proxy should be rejected, got %d %s", link, errCode, errMsg)
+		}
+
+		isSucceeded, errCode, errMsg, _ = Account(link, "someone@crossbell")
+		if isSucceeded || errCode != commonConsts.ERROR_CODE_INVALID_FORMAT {
+			t.Errorf("validating %s through proxy should be rejected, got %d %s", link, errCode, errMsg)
+		}
+	}
+
+	if proxied != 0 {
+		t.Errorf("should never ask proxy for private address, got %d requests", proxied)
+	}
+}
@@ -0,0 +1,13 @@
+package custom_feed
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
+	_ "github.com/Crossbell-Box/OperatorSync/common/platforms/builtin" // Meta of platform
+)
+
+func init() {
+	platforms.Register("custom_feed", &platforms.Funcs{
+		AccountFunc: Account,
+		FeedsFunc:   Feeds,
+	})
+}
@@ -1,9 +1,7 @@
 package jike
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
@@ -20,35 +18,31 @@ func init() {
 	imageRegex = regexp.MustCompile(`<img[^>]+\bsrc=["']([^"']+)["'].*?/?>`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://rsshub.app/jike/user/3EE02BC9-C5B3-4209-8750-4ED1EE0F67BB
 
-	// Concurrency control
-	cccs.Stateless.Request()
-	defer cccs.Stateless.Done()
-
 	global.Logger.Debug("New feeds request for jike")
 
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateless}}",
-			config.Config.RSSHubEndpointStateless,
-		)
-
-	rawFeed, errCode, err := utils.RSSFeedRequestJson(
-		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
+	feedLink := strings.ReplaceAll(collectLink, "{{username}}", work.Username)
+	rawFeed, errCode, err := utils.RSSFeedRequestJsonConditional(
+		work,
+		feedLink,
 		true,
 	)
 	if err != nil {
 		return false, nil, errCode, err.Error()
 	}
 
+	// Fetch deeper if feeds might be missed
+	rawFeed = utils.RSSHubCollectDeeperJson(work, rawFeed, feedLink, true)
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.ID)
+
 		if item.DatePublished.After(work.DropBefore) && item.DatePublished.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Link:        item.URL,
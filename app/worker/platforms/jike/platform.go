@@ -0,0 +1,13 @@
+package jike
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
+	_ "github.com/Crossbell-Box/OperatorSync/common/platforms/builtin" // Meta of platform
+)
+
+func init() {
+	platforms.Register("jike", &platforms.Funcs{
+		AccountFunc: Account,
+		FeedsFunc:   Feeds,
+	})
+}
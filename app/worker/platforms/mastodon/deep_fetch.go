@@ -0,0 +1,97 @@
+package mastodon
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"net/url"
+	"path"
+	"time"
+)
+
+type apiAccount struct {
+	ID string `json:"id"`
+}
+
+type apiMediaAttachment struct {
+	URL string `json:"url"`
+}
+
+type apiStatus struct {
+	ID               string               `json:"id"`
+	URL              string               `json:"url"`
+	CreatedAt        time.Time            `json:"created_at"`
+	Content          string               `json:"content"`
+	MediaAttachments []apiMediaAttachment `json:"media_attachments"`
+}
+
+// apiFetcher : RSS only contains latest statuses, older ones are paginated with max_id in API
+func apiFetcher(instance string, username string) utils.FeedPageFetcher {
+	var accountID string
+
+	return func(_ int, oldest *gofeed.Item) ([]*gofeed.Item, bool, error) {
+		if accountID == "" {
+			accountBytes, err := utils.HttpRequest(fmt.Sprintf("https://%s/api/v1/accounts/lookup?acct=%s", instance, url.QueryEscape(username)), true)
+			if err != nil {
+				return nil, false, err
+			}
+			var account apiAccount
+			if err := json.Unmarshal(accountBytes, &account); err != nil {
+				return nil, false, err
+			}
+			accountID = account.ID
+		}
+
+		// GUID in RSS is status URL, ends with status ID
+		statusesBytes, err := utils.HttpRequest(fmt.Sprintf(
+			"https://%s/api/v1/accounts/%s/statuses?exclude_replies=true&exclude_reblogs=true&max_id=%s&limit=%d",
+			instance, url.PathEscape(accountID), url.QueryEscape(path.Base(oldest.GUID)), consts.MASTODON_DEEP_FETCH_LIMIT,
+		), true)
+		if err != nil {
+			return nil, false, err
+		}
+		var statuses []apiStatus
+		if err := json.Unmarshal(statusesBytes, &statuses); err != nil {
+			return nil, false, err
+		}
+
+		var items []*gofeed.Item
+		for _, status := range statuses {
+			items = append(items, statusToItem(status))
+		}
+
+		return items, len(statuses) == 0, nil
+	}
+}
+
+// statusToItem : Same fields as RSS items, so they can be processed together
+func statusToItem(status apiStatus) *gofeed.Item {
+	publishedAt := status.CreatedAt
+
+	item := &gofeed.Item{
+		GUID:            status.URL,
+		Link:            status.URL,
+		Description:     status.Content,
+		PublishedParsed: &publishedAt,
+	}
+
+	if len(status.MediaAttachments) > 0 {
+		var medias []ext.Extension
+		for _, media := range status.MediaAttachments {
+			medias = append(medias, ext.Extension{
+				Name:  "content",
+				Attrs: map[string]string{"url": media.URL},
+			})
+		}
+		item.Extensions = ext.Extensions{
+			"media": {
+				"content": medias,
+			},
+		}
+	}
+
+	return item
+}
@@ -2,7 +2,6 @@ package mastodon
 
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
@@ -21,15 +20,11 @@ func init() {
 	customEmojisRegex = regexp.MustCompile(`<img[^>]+\bsrc=["']([^"']+)["']`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://eihei.net/@candinya.rss
 
-	// Concurrency control
-	cccs.Direct.Request()
-	defer cccs.Direct.Done()
-
 	global.Logger.Debug("New feeds request for mastodon")
 
 	username, instance, err := commonUtils.SplitFediverseUsernameInstance(work.Username)
@@ -40,7 +35,8 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 	collectLink = strings.Replace(collectLink, "{{instance}}", instance, 1)
 	collectLink = strings.Replace(collectLink, "{{username}}", username, 1)
 
-	rawFeed, errCode, err := utils.RSSFeedRequest(
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(
+		work,
 		collectLink,
 		true,
 	)
@@ -48,9 +44,19 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 		return false, nil, errCode, err.Error()
 	}
 
+	// Fetch deeper if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, apiFetcher(instance, username))
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			// Unable to tell if collected before
+			continue
+		}
+
 		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Link:        item.Link,
@@ -0,0 +1,13 @@
+package mastodon
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
+	_ "github.com/Crossbell-Box/OperatorSync/common/platforms/builtin" // Meta of platform
+)
+
+func init() {
+	platforms.Register("mastodon", &platforms.Funcs{
+		AccountFunc: Account,
+		FeedsFunc:   Feeds,
+	})
+}
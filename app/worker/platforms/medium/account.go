@@ -3,6 +3,7 @@ package medium
 import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	"regexp"
@@ -25,7 +26,7 @@ func Account(username string, validateString string) (bool, uint, string, bool)
 	pageContent, err := utils.HttpRequest(fmt.Sprintf("https://medium.com/@%s", username), true)
 	if err != nil {
 		global.Logger.Error("Failed to check medium page of user ", username, " for account validate with error: ", err.Error())
-		return false, commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, err.Error(), false
+		return false, httpclient.ErrorCode(err), err.Error(), false
 	} else {
 		description := mediumDescriptionRegex.FindStringSubmatch(string(pageContent))
 		if len(description) < 2 {
@@ -0,0 +1,54 @@
+package nostr
+
+import (
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"strings"
+)
+
+func Account(username string, validateString string) (bool, uint, string, bool) {
+
+	global.Logger.Debug("Validate string: ", validateString)
+
+	pubKey, errCode, err := resolvePubKey(username)
+	if err != nil {
+		global.Logger.Error("Failed to resolve nostr public key of ", username, " with error: ", err.Error())
+		return false, errCode, err.Error(), false
+	}
+
+	events, err := queryRelays(&Filter{
+		Authors: []string{pubKey},
+		Kinds:   []int{kindMetadata},
+		Limit:   1,
+	})
+	if err != nil {
+		global.Logger.Error("Failed to get nostr profile of ", username, " with error: ", err.Error())
+		return false, commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, err.Error(), false
+	}
+
+	// Events are sorted, newest profile first
+	for _, e := range events {
+		if e.PubKey != pubKey || e.Kind != kindMetadata {
+			continue
+		}
+
+		var profile ProfileMetadata
+		if err := json.Unmarshal([]byte(e.Content), &profile); err != nil {
+			global.Logger.Error("Failed to parse nostr profile of ", username, " with error: ", err.Error())
+			return false, commonConsts.ERROR_CODE_FAILED_TO_PARSE_JSON, err.Error(), false
+		}
+
+		if strings.Contains(strings.ToLower(profile.About), validateString) {
+			global.Logger.Debug("Account verify succeeded")
+			return true, 0, "", true
+		} else {
+			global.Logger.Debug("No validate string found: ", profile.About)
+			return true, 0, "", false
+		}
+	}
+
+	global.Logger.Error("Failed to find nostr profile of ", username)
+	return false, commonConsts.ERROR_CODE_FAILED_TO_FIND_NECESSARY_FIELD, "Failed to find profile", false
+
+}
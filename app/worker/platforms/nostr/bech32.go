@@ -0,0 +1,115 @@
+package nostr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Minimal bech32 (BIP-173) for NIP-19 keys and ids, like npub1... / note1...
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	result := make([]byte, 0, len(hrp)*2+1)
+	for _, c := range hrp {
+		result = append(result, byte(c)>>5)
+	}
+	result = append(result, 0)
+	for _, c := range hrp {
+		result = append(result, byte(c)&31)
+	}
+	return result
+}
+
+// convertBits : Regroup bits, like 8 bits bytes <-> 5 bits words
+func convertBits(data []byte, fromBits uint, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+	var result []byte
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data range")
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxValue))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return result, nil
+}
+
+func bech32Decode(s string) (string, []byte, error) {
+	s = strings.ToLower(s)
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, fmt.Errorf("invalid bech32 string")
+	}
+
+	hrp := s[:pos]
+	var words []byte
+	for _, c := range s[pos+1:] {
+		index := strings.IndexRune(bech32Charset, c)
+		if index < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %c", c)
+		}
+		words = append(words, byte(index))
+	}
+
+	if bech32Polymod(append(bech32HrpExpand(hrp), words...)) != 1 {
+		return "", nil, fmt.Errorf("invalid bech32 checksum")
+	}
+
+	data, err := convertBits(words[:len(words)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hrp, data, nil
+}
+
+func bech32Encode(hrp string, data []byte) (string, error) {
+	words, err := convertBits(data, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	values := append(bech32HrpExpand(hrp), words...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ 1
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, w := range words {
+		sb.WriteByte(bech32Charset[w])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+
+	return sb.String(), nil
+}
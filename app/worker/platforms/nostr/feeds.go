@@ -0,0 +1,129 @@
+package nostr
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	imageLinkRegex *regexp.Regexp
+)
+
+func init() {
+	imageLinkRegex = regexp.MustCompile(`(?i)https?://\S+?\.(png|jpe?g|gif|webp)(\?\S*)?\b`)
+}
+
+func Feeds(work *commonTypes.WorkDispatched, _ string) (
+	bool, []commonTypes.RawFeed, uint, string,
+) {
+	// Refer to https://github.com/nostr-protocol/nips/blob/master/01.md
+
+	global.Logger.Debug("New feeds request for nostr")
+
+	pubKey, errCode, err := resolvePubKey(work.Username)
+	if err != nil {
+		return false, nil, errCode, err.Error()
+	}
+
+	events, err := queryRelays(&Filter{
+		Authors: []string{pubKey},
+		Kinds:   []int{kindTextNote},
+		Since:   work.DropBefore.Unix(),
+		Until:   work.DropAfter.Unix(),
+		Limit:   100,
+	})
+	if err != nil {
+		return false, nil, commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, err.Error()
+	}
+
+	var feeds []commonTypes.RawFeed
+
+	for _, e := range events {
+		utils.ProcessingItem(work, e.ID)
+
+		if e.PubKey != pubKey || e.Kind != kindTextNote {
+			continue
+		}
+
+		publishedAt := time.Unix(e.CreatedAt, 0)
+		if !(publishedAt.After(work.DropBefore) && publishedAt.Before(work.DropAfter)) {
+			continue
+		}
+
+		feed := parseEvent(&e)
+
+		// Upload images in content
+		images := imageLinkRegex.FindAllString(feed.Content, -1)
+		feed.Media = utils.UploadAllMedia(images)
+		for _, media := range feed.Media {
+			feed.Content = strings.ReplaceAll(feed.Content, media.OriginalURI, fmt.Sprintf("![](%s)", media.IPFSUri))
+		}
+
+		feeds = append(feeds, *feed)
+	}
+
+	return true, feeds, 0, ""
+}
+
+func parseEvent(e *Event) *commonTypes.RawFeed {
+	feed := commonTypes.RawFeed{
+		Content:     e.Content,
+		Link:        noteURI(e.ID),
+		GUID:        e.ID,
+		PublishedAt: time.Unix(e.CreatedAt, 0),
+	}
+
+	var (
+		rootID        string
+		replyID       string
+		lastUnmarked  string
+		hasMarkedTags bool
+	)
+
+	for _, tag := range e.Tags {
+		if len(tag) < 2 {
+			continue
+		}
+		switch tag[0] {
+		case "e":
+			// NIP-10: marked tags, or deprecated positional ones (last one is reply)
+			if len(tag) >= 4 && tag[3] != "" {
+				hasMarkedTags = true
+				switch tag[3] {
+				case "root":
+					rootID = tag[1]
+				case "reply":
+					replyID = tag[1]
+				}
+			} else {
+				lastUnmarked = tag[1]
+			}
+		case "t":
+			feed.Categories = append(feed.Categories, tag[1])
+		case "content-warning":
+			feed.ContentWarning = "sensitive"
+		case "subject":
+			feed.Title = tag[1]
+		}
+	}
+
+	// Reply to root directly if no reply marked
+	parentID := replyID
+	if parentID == "" {
+		parentID = rootID
+	}
+	if parentID == "" && !hasMarkedTags {
+		parentID = lastUnmarked
+	}
+	if parentID != "" {
+		feed.ForURI = noteURI(parentID)
+	}
+
+	return &feed
+}
@@ -0,0 +1,118 @@
+package nostr
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signEvent(t *testing.T, privKey *btcec.PrivateKey, e *Event) {
+	e.PubKey = hex.EncodeToString(schnorr.SerializePubKey(privKey.PubKey()))
+	id, err := e.calcID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.ID = id
+	idBytes, _ := hex.DecodeString(id)
+	sig, err := schnorr.Sign(privKey, idBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Sig = hex.EncodeToString(sig.Serialize())
+}
+
+func TestFeeds(t *testing.T) {
+	// Init deps
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync() // Unable to handle errors here
+	global.Logger = logger.Sugar()
+
+	privKey, _ := btcec.NewPrivateKey()
+	publishedAt := time.Date(2023, 5, 1, 8, 0, 0, 0, time.UTC).Unix()
+
+	parentID := strings.Repeat("ab", 32)
+	profile := Event{CreatedAt: publishedAt, Kind: kindMetadata, Content: `{"name": "alice", "about": "alice@Crossbell <3"}`}
+	note := Event{CreatedAt: publishedAt, Kind: kindTextNote, Content: "Hello & reply", Tags: [][]string{
+		{"e", strings.Repeat("cd", 32), "", "root"},
+		{"e", parentID, "", "reply"},
+		{"t", "test"},
+	}}
+	forged := Event{CreatedAt: publishedAt, Kind: kindTextNote, Content: "Forged"}
+	signEvent(t, privKey, &profile)
+	signEvent(t, privKey, &note)
+	signEvent(t, privKey, &forged)
+	forged.Content = "Modified by relay"
+
+	// Local stand-in relay
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req []json.RawMessage
+		if err := conn.ReadJSON(&req); err != nil || len(req) < 3 {
+			return
+		}
+		var subscriptionID string
+		var filter Filter
+		_ = json.Unmarshal(req[1], &subscriptionID)
+		_ = json.Unmarshal(req[2], &filter)
+
+		for _, e := range []Event{profile, note, forged} {
+			if e.Kind == filter.Kinds[0] {
+				_ = conn.WriteJSON([]interface{}{"EVENT", subscriptionID, e})
+			}
+		}
+		_ = conn.WriteJSON([]interface{}{"EOSE", subscriptionID})
+		_, _, _ = conn.ReadMessage() // CLOSE
+	}))
+	defer server.Close()
+	httpclient.TrustHost(server.URL)
+	config.Config.NostrRelays = []string{"ws" + strings.TrimPrefix(server.URL, "http")}
+
+	npub, _ := bech32Encode("npub", schnorr.SerializePubKey(privKey.PubKey()))
+
+	isSucceeded, feeds, errCode, errMsg := Feeds(&commonTypes.WorkDispatched{
+		Username:   npub,
+		DropBefore: time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC),
+		DropAfter:  time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC),
+	}, "")
+	if !isSucceeded {
+		t.Fatal(errCode, errMsg)
+	}
+	if len(feeds) != 1 {
+		t.Fatalf("expected 1 feed (forged one dropped), got %v", feeds)
+	}
+	if feeds[0].ForURI != noteURI(parentID) || !strings.HasPrefix(feeds[0].ForURI, "nostr:note1") || feeds[0].Content != "Hello & reply" {
+		t.Errorf("unexpected feed: %v", feeds[0])
+	}
+
+	if _, _, _, isValid := Account(npub, "alice@crossbell"); !isValid {
+		t.Error("validate string not found in profile")
+	}
+}
+
+func TestResolvePubKey(t *testing.T) {
+	pubKey, _, err := resolvePubKey("npub1sg6plzptd64u62a878hep2kev88swjh3tw00gjsfl8f237lmu63q0uf63m")
+	if err != nil || pubKey != "82341f882b6eabcd2ba7f1ef90aad961cf074af15b9ef44a09f9d2a8fbfbe6a2" {
+		t.Errorf("unexpected public key %s (%v)", pubKey, err)
+	}
+	if _, _, err := resolvePubKey("npub1invalid"); err == nil {
+		t.Error("invalid npub should be rejected")
+	}
+}
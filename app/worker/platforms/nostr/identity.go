@@ -0,0 +1,77 @@
+package nostr
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	hexKeyRegex *regexp.Regexp
+)
+
+func init() {
+	hexKeyRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+}
+
+// resolvePubKey : Username might be npub (NIP-19), NIP-05 identifier or hex public key
+func resolvePubKey(username string) (string, uint, error) {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "nostr:")
+
+	if strings.HasPrefix(strings.ToLower(username), "npub1") {
+		hrp, data, err := bech32Decode(username)
+		if err != nil || hrp != "npub" || len(data) != 32 {
+			return "", commonConsts.ERROR_CODE_INVALID_FORMAT, fmt.Errorf("invalid npub: %s", username)
+		}
+		return hex.EncodeToString(data), 0, nil
+	}
+
+	if hexKeyRegex.MatchString(username) {
+		return username, 0, nil
+	}
+
+	// NIP-05: name@domain, refer to https://github.com/nostr-protocol/nips/blob/master/05.md
+	name, domain := "_", username
+	if splits := strings.Split(username, "@"); len(splits) == 2 {
+		name, domain = strings.ToLower(splits[0]), splits[1]
+	}
+	if !strings.Contains(domain, ".") && !strings.Contains(domain, ":") {
+		return "", commonConsts.ERROR_CODE_INVALID_FORMAT, fmt.Errorf("invalid nostr identifier: %s", username)
+	}
+
+	body, err := utils.HttpRequest(fmt.Sprintf("https://%s/.well-known/nostr.json?name=%s", domain, url.QueryEscape(name)), true)
+	if err != nil {
+		return "", httpclient.ErrorCode(err), err
+	}
+
+	var res NIP05Response
+	if err := json.Unmarshal(body, &res); err != nil {
+		return "", commonConsts.ERROR_CODE_FAILED_TO_PARSE_JSON, err
+	}
+
+	pubKey, ok := res.Names[name]
+	if !ok || !hexKeyRegex.MatchString(pubKey) {
+		return "", commonConsts.ERROR_CODE_FAILED_TO_FIND_NECESSARY_FIELD, fmt.Errorf("no public key found for %s", username)
+	}
+
+	return pubKey, 0, nil
+}
+
+// noteURI : nostr:note1... (NIP-21), used as link so replies can refer to it
+func noteURI(eventID string) string {
+	idBytes, err := hex.DecodeString(eventID)
+	if err != nil || len(idBytes) != 32 {
+		return ""
+	}
+	note, err := bech32Encode("note", idBytes)
+	if err != nil {
+		return ""
+	}
+	return "nostr:" + note
+}
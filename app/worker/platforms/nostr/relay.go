@@ -0,0 +1,150 @@
+package nostr
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	relayTimeout = 10 * time.Second // Connect and wait for EOSE
+)
+
+// queryRelays : Send filter to all configured relays, returns verified and deduplicated events (newest first)
+func queryRelays(filter *Filter) ([]Event, error) {
+	relays := config.Config.NostrRelays
+	if len(relays) == 0 {
+		return nil, fmt.Errorf("no relays configured")
+	}
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		events   = make(map[string]Event)
+		failures int
+	)
+
+	for _, relay := range relays {
+		innerRelay := relay
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			relayEvents, err := func() (relayEvents []Event, err error) {
+				// Out of work goroutine, recover here or the worker goes down
+				defer func() {
+					if workPanic := utils.RecoverPanic(recover()); workPanic != nil {
+						global.Logger.Errorf("Nostr relay %s %s\n%s", innerRelay, workPanic.Error(), workPanic.Stack)
+						err = workPanic
+					}
+				}()
+				return queryRelay(innerRelay, filter)
+			}()
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if err != nil {
+				global.Logger.Warnf("Failed to query nostr relay %s with error: %s", innerRelay, err.Error())
+				failures++
+			}
+			for _, e := range relayEvents {
+				if _, ok := events[e.ID]; ok {
+					continue
+				}
+				if e.Verify() {
+					events[e.ID] = e
+				} else {
+					global.Logger.Warnf("Invalid event %s from nostr relay %s", e.ID, innerRelay)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if failures == len(relays) {
+		return nil, fmt.Errorf("all relays failed")
+	}
+
+	result := make([]Event, 0, len(events))
+	for _, e := range events {
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt > result[j].CreatedAt
+	})
+
+	return result, nil
+}
+
+// queryRelay : REQ, collect EVENTs until EOSE, then CLOSE
+func queryRelay(relay string, filter *Filter) ([]Event, error) {
+	dialer := websocket.Dialer{
+		HandshakeTimeout: relayTimeout,
+		NetDialContext:   httpclient.DialContext,
+	}
+	if config.Config.ProxyURL != nil {
+		dialer.Proxy = http.ProxyURL(config.Config.ProxyURL)
+	}
+
+	conn, _, err := dialer.Dial(relay, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(relayTimeout)
+	_ = conn.SetReadDeadline(deadline)
+	_ = conn.SetWriteDeadline(deadline)
+
+	subscriptionID := fmt.Sprintf("cos-%d", time.Now().UnixNano())
+	if err := conn.WriteJSON([]interface{}{"REQ", subscriptionID, filter}); err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for {
+		var msg []json.RawMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return events, err
+		}
+		if len(msg) < 2 {
+			continue
+		}
+
+		var msgType, msgSubscriptionID string
+		_ = json.Unmarshal(msg[0], &msgType)
+		_ = json.Unmarshal(msg[1], &msgSubscriptionID)
+
+		switch msgType {
+		case "EVENT":
+			if msgSubscriptionID != subscriptionID || len(msg) < 3 {
+				continue
+			}
+			var e Event
+			if err := json.Unmarshal(msg[2], &e); err == nil {
+				events = append(events, e)
+			}
+		case "EOSE":
+			if msgSubscriptionID == subscriptionID {
+				_ = conn.WriteJSON([]interface{}{"CLOSE", subscriptionID})
+				return events, nil
+			}
+		case "CLOSED":
+			if msgSubscriptionID == subscriptionID {
+				return events, fmt.Errorf("subscription closed by relay: %s", string(msg[len(msg)-1]))
+			}
+		case "NOTICE":
+			global.Logger.Debugf("Notice from nostr relay %s: %s", relay, string(msg[1]))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package nostr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+)
+
+// Refer to https://github.com/nostr-protocol/nips/blob/master/01.md
+
+const (
+	kindMetadata = 0
+	kindTextNote = 1
+)
+
+type Event struct {
+	ID        string     `json:"id"`
+	PubKey    string     `json:"pubkey"`
+	CreatedAt int64      `json:"created_at"`
+	Kind      int        `json:"kind"`
+	Tags      [][]string `json:"tags"`
+	Content   string     `json:"content"`
+	Sig       string     `json:"sig"`
+}
+
+type Filter struct {
+	Authors []string `json:"authors,omitempty"`
+	Kinds   []int    `json:"kinds,omitempty"`
+	Since   int64    `json:"since,omitempty"`
+	Until   int64    `json:"until,omitempty"`
+	Limit   int      `json:"limit,omitempty"`
+}
+
+type ProfileMetadata struct {
+	Name  string `json:"name"`
+	About string `json:"about"`
+}
+
+type NIP05Response struct {
+	Names map[string]string `json:"names"`
+}
+
+// calcID : sha256 of [0, pubkey, created_at, kind, tags, content]
+func (e *Event) calcID() (string, error) {
+	tags := e.Tags
+	if tags == nil {
+		tags = [][]string{}
+	}
+	// No HTML escape, and no trailing newline
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode([]interface{}{0, e.PubKey, e.CreatedAt, e.Kind, tags, e.Content}); err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// Verify : Check event id and signature, as relays are not trusted
+func (e *Event) Verify() bool {
+	id, err := e.calcID()
+	if err != nil || id != e.ID {
+		return false
+	}
+
+	idBytes, err := hex.DecodeString(e.ID)
+	if err != nil {
+		return false
+	}
+	pubKeyBytes, err := hex.DecodeString(e.PubKey)
+	if err != nil {
+		return false
+	}
+	sigBytes, err := hex.DecodeString(e.Sig)
+	if err != nil {
+		return false
+	}
+
+	pubKey, err := schnorr.ParsePubKey(pubKeyBytes)
+	if err != nil {
+		return false
+	}
+	sig, err := schnorr.ParseSignature(sigBytes)
+	if err != nil {
+		return false
+	}
+
+	return sig.Verify(idBytes, pubKey)
+}
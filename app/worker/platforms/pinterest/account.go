@@ -3,7 +3,7 @@ package pinterest
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"strings"
 )
 
@@ -11,7 +11,8 @@ func Account(username string, validateString string) (bool, uint, string, bool)
 
 	global.Logger.Debug("Validate string: ", validateString)
 
-	collectLink := commonConsts.SUPPORTED_PLATFORM["pinterest"].FeedLink
+	platformMeta, _ := commonPlatforms.Meta("pinterest")
+	collectLink := platformMeta.FeedLink
 
 	if rawFeed, errCode, err := utils.RSSFeedRequest(
 		strings.ReplaceAll(collectLink, "{{username}}", username),
@@ -2,7 +2,6 @@ package pinterest
 
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"regexp"
@@ -23,18 +22,15 @@ func init() {
 	leadingSpaces = regexp.MustCompile(`^[\s\n\r]+`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://www.pinterest.com/ncandy0418/feed.rss
 
-	// Concurrency control
-	cccs.Direct.Request()
-	defer cccs.Direct.Done()
-
 	global.Logger.Debug("New feeds request for pinterest")
 
-	rawFeed, errCode, err := utils.RSSFeedRequest(
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(
+		work,
 		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
 		true,
 	)
@@ -42,9 +38,19 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 		return false, nil, errCode, err.Error()
 	}
 
+	// No pagination on source, just flag the gap if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, nil)
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			// Unable to tell if collected before
+			continue
+		}
+
 		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Title:       item.Title,
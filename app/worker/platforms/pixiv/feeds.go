@@ -1,9 +1,7 @@
 package pixiv
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"regexp"
@@ -20,36 +18,37 @@ func init() {
 	pixivImageRegex = regexp.MustCompile(`<img[^>]+\bsrc=["']([^"']+)["'].*?/?>`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 
 	// Refer to https://rsshub.app/pixiv/user/87178177
 
-	// Concurrency control
-	cccs.Stateful.Request()
-	defer cccs.Stateful.Done()
-
 	global.Logger.Debug("New feeds request for pixiv")
 
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateful}}",
-			config.Config.RSSHubEndpointStateful,
-		)
-
-	rawFeed, errCode, err := utils.RSSFeedRequest(
-		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
+	feedLink := strings.ReplaceAll(collectLink, "{{username}}", work.Username)
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(
+		work,
+		feedLink,
 		true,
 	)
 	if err != nil {
 		return false, nil, errCode, err.Error()
 	}
 
+	// Fetch deeper if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, utils.RSSHubDeepFetcher(feedLink, true))
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			// Unable to tell if collected before
+			continue
+		}
+
 		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Title:       item.Title,
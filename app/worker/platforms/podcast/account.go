@@ -0,0 +1,44 @@
+package podcast
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"strings"
+)
+
+func Account(username string, validateString string) (bool, uint, string, bool) {
+
+	global.Logger.Debug("Validate string: ", validateString)
+
+	feedLink, err := utils.ParseHttpUri(username) // Username is feed URL
+	if err != nil {
+		return false, commonConsts.ERROR_CODE_INVALID_FORMAT, err.Error(), false
+	}
+
+	rawFeed, errCode, err := utils.RSSFeedRequest(feedLink, true)
+	if err != nil {
+		global.Logger.Error("Failed to request podcast feed ", feedLink, " for account validate with error: ", err.Error())
+		return false, errCode, err.Error(), false
+	}
+
+	// Owner and descriptions
+	fields := []string{rawFeed.Description}
+	if rawFeed.ITunesExt != nil {
+		fields = append(fields, rawFeed.ITunesExt.Summary)
+		if rawFeed.ITunesExt.Owner != nil {
+			fields = append(fields, rawFeed.ITunesExt.Owner.Name, rawFeed.ITunesExt.Owner.Email)
+		}
+	}
+
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), validateString) {
+			global.Logger.Debug("Account verify succeeded")
+			return true, 0, "", true
+		}
+	}
+
+	global.Logger.Debug("No validate string found in podcast owner or description: ", rawFeed.Description)
+	return true, 0, "", false
+
+}
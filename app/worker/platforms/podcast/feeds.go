@@ -0,0 +1,180 @@
+package podcast
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+	"strconv"
+	"strings"
+)
+
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
+	bool, []commonTypes.RawFeed, uint, string,
+) {
+	// Any podcast RSS, refer to https://help.apple.com/itc/podcasts_connect/#/itcb54353390
+
+	global.Logger.Debug("New feeds request for podcast")
+
+	feedLink, err := utils.ParseHttpUri(strings.ReplaceAll(collectLink, "{{username}}", work.Username))
+	if err != nil {
+		return false, nil, commonConsts.ERROR_CODE_INVALID_FORMAT, err.Error()
+	}
+
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(work, feedLink, true)
+	if err != nil {
+		return false, nil, errCode, err.Error()
+	}
+
+	// No pagination on source, just flag the gap if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, nil)
+
+	// Show cover, used when episode has no cover
+	showCover := ""
+	if rawFeed.ITunesExt != nil && rawFeed.ITunesExt.Image != "" {
+		showCover = rawFeed.ITunesExt.Image
+	} else if rawFeed.Image != nil {
+		showCover = rawFeed.Image.URL
+	}
+
+	var feeds []commonTypes.RawFeed
+
+	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			continue
+		}
+
+		audio := findAudioEnclosure(item)
+		if audio == nil {
+			// Not an episode
+			continue
+		}
+
+		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
+			feed := commonTypes.RawFeed{
+				Title:       item.Title,
+				Description: item.Description,
+				Link:        item.Link,
+				GUID:        item.GUID,
+				Categories:  item.Categories,
+				Authors:     utils.ParseAuthors(item.Authors),
+				PublishedAt: *item.PublishedParsed,
+				Attributes:  parseAttributes(item.ITunesExt),
+			}
+
+			rawContent := item.Content
+			if rawContent == "" {
+				rawContent = item.Description
+			}
+			if rawContent == "" && item.ITunesExt != nil {
+				rawContent = item.ITunesExt.Summary
+			}
+			feed.Content = rawContent
+
+			// Audio first
+			audioMedia, err := utils.UploadOneMedia(audio.URL)
+			if err != nil {
+				// Too large or unavailable, would fail every time, so keep the episode with link of audio instead
+				global.Logger.Warnf("Failed to upload audio %s of episode %s with error: %s", audio.URL, item.GUID, err.Error())
+				feed.Attributes = append(feed.Attributes, commonTypes.FeedAttribute{
+					TraitType: "audio",
+					Value:     audio.URL,
+				})
+			} else {
+				if !strings.HasPrefix(audioMedia.ContentType, "audio/") {
+					// Detected by content, might be like video/mp4 for m4a
+					audioMedia.ContentType = audio.Type
+				}
+				feed.Media = append(feed.Media, *audioMedia)
+			}
+
+			// Then cover art
+			cover := showCover
+			if item.ITunesExt != nil && item.ITunesExt.Image != "" {
+				cover = item.ITunesExt.Image
+			} else if item.Image != nil && item.Image.URL != "" {
+				cover = item.Image.URL
+			}
+			if cover != "" {
+				coverMedia, err := utils.UploadOneMedia(cover)
+				if err != nil {
+					// Not necessary, just log it
+					global.Logger.Warnf("Failed to upload cover %s of episode %s with error: %s", cover, item.GUID, err.Error())
+				} else {
+					feed.Image = coverMedia.IPFSUri
+					feed.Media = append(feed.Media, *coverMedia)
+				}
+			}
+
+			feeds = append(feeds, feed)
+
+		}
+	}
+
+	return true, feeds, 0, ""
+
+}
+
+func findAudioEnclosure(item *gofeed.Item) *gofeed.Enclosure {
+	for _, e := range item.Enclosures {
+		if strings.HasPrefix(e.Type, "audio/") && e.URL != "" {
+			return e
+		}
+	}
+	return nil
+}
+
+func parseAttributes(itunes *ext.ITunesItemExtension) commonTypes.FeedAttributes {
+	if itunes == nil {
+		return nil
+	}
+
+	var attributes commonTypes.FeedAttributes
+
+	if duration, ok := parseDuration(itunes.Duration); ok {
+		attributes = append(attributes, commonTypes.FeedAttribute{
+			TraitType:   "duration",
+			Value:       duration,
+			DisplayType: "number",
+		})
+	}
+	if episode, err := strconv.Atoi(itunes.Episode); err == nil {
+		attributes = append(attributes, commonTypes.FeedAttribute{
+			TraitType:   "episode",
+			Value:       episode,
+			DisplayType: "number",
+		})
+	}
+	if season, err := strconv.Atoi(itunes.Season); err == nil {
+		attributes = append(attributes, commonTypes.FeedAttribute{
+			TraitType:   "season",
+			Value:       season,
+			DisplayType: "number",
+		})
+	}
+
+	return attributes
+}
+
+// parseDuration : itunes:duration in seconds, formats like 3723, 62:03 or 1:02:03
+func parseDuration(raw string) (int, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, false
+	}
+
+	seconds := 0
+	for _, part := range strings.Split(raw, ":") {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value < 0 {
+			return 0, false
+		}
+		seconds = seconds*60 + int(value)
+	}
+
+	return seconds, true
+}
@@ -0,0 +1,105 @@
+package podcast
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPodcastFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+<channel>
+  <title>Test Show</title>
+  <itunes:owner><itunes:name>Alice</itunes:name><itunes:email>alice@crossbell</itunes:email></itunes:owner>
+  <item>
+    <title>Episode 3</title>
+    <guid>ep3</guid>
+    <pubDate>Mon, 01 May 2023 08:00:00 GMT</pubDate>
+    <enclosure url="https://example.com/ep3.m4a" length="123" type="audio/x-m4a"/>
+    <itunes:duration>1:02:03</itunes:duration>
+    <itunes:episode>3</itunes:episode>
+    <itunes:season>1</itunes:season>
+  </item>
+  <item>
+    <title>Trailer video</title>
+    <enclosure url="https://example.com/trailer.mp4" length="123" type="video/mp4"/>
+  </item>
+</channel>
+</rss>`
+
+func TestParseEpisode(t *testing.T) {
+	rawFeed, err := gofeed.NewParser().ParseString(testPodcastFeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rawFeed.ITunesExt.Owner.Email != "alice@crossbell" {
+		t.Errorf("unexpected owner: %v", rawFeed.ITunesExt.Owner)
+	}
+
+	if audio := findAudioEnclosure(rawFeed.Items[0]); audio == nil || audio.URL != "https://example.com/ep3.m4a" {
+		t.Errorf("unexpected audio enclosure: %v", audio)
+	}
+	if audio := findAudioEnclosure(rawFeed.Items[1]); audio != nil {
+		t.Errorf("video should not be treated as episode: %v", audio)
+	}
+
+	attributes := parseAttributes(rawFeed.Items[0].ITunesExt)
+	if len(attributes) != 3 || attributes[0].TraitType != "duration" || attributes[0].Value != 3723 {
+		t.Errorf("unexpected attributes: %v", attributes)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for raw, expected := range map[string]int{"3723": 3723, "62:03": 3723, "1:02:03": 3723} {
+		if seconds, ok := parseDuration(raw); !ok || seconds != expected {
+			t.Errorf("unexpected duration %d for %s", seconds, raw)
+		}
+	}
+	if _, ok := parseDuration("about an hour"); ok {
+		t.Error("invalid duration should be rejected")
+	}
+}
+
+func TestFeedsAudioUploadFailed(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	global.Logger = logger.Sugar()
+	config.Config.HttpRetries = 0
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed.xml":
+			_, _ = w.Write([]byte(strings.Replace(testPodcastFeed, "https://example.com", server.URL, -1)))
+		default:
+			// Audio unavailable, like too large to download
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	httpclient.TrustHost(server.URL)
+
+	isSucceeded, feeds, errCode, errMsg := Feeds(&commonTypes.WorkDispatched{
+		Username:  "feed.xml",
+		DropAfter: time.Now(),
+	}, server.URL+"/{{username}}")
+	if !isSucceeded {
+		t.Fatalf("episode with audio failed to upload should not fail the work, got %d %s", errCode, errMsg)
+	}
+	if len(feeds) != 1 || len(feeds[0].Media) != 0 {
+		t.Fatalf("episode should be kept without media, got %v", feeds)
+	}
+
+	attributes := feeds[0].Attributes
+	if audio := attributes[len(attributes)-1]; audio.TraitType != "audio" || audio.Value != server.URL+"/ep3.m4a" {
+		t.Errorf("audio link should be recorded, got %v", attributes)
+	}
+}
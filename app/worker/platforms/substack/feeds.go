@@ -2,7 +2,6 @@ package substack
 
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"regexp"
@@ -24,18 +23,15 @@ func init() {
 
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://lc499.substack.com/feed
 
-	// Concurrency control
-	cccs.Direct.Request()
-	defer cccs.Direct.Done()
-
 	global.Logger.Debug("New feeds request for substack")
 
-	rawFeed, errCode, err := utils.RSSFeedRequest(
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(
+		work,
 		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
 		true,
 	)
@@ -43,9 +39,19 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 		return false, nil, errCode, err.Error()
 	}
 
+	// No pagination on source, just flag the gap if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, nil)
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			// Unable to tell if collected before
+			continue
+		}
+
 		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Title:       item.Title,
@@ -1,10 +1,9 @@
 package tg_channel
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"strings"
 )
 
@@ -14,14 +13,8 @@ func Account(username string, validateString string) (bool, uint, string, bool)
 
 	// RSSHub will index userinfo, so we need to parse their feeds
 
-	collectLink := commonConsts.SUPPORTED_PLATFORM["tg_channel"].FeedLink
-
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateless}}",
-			config.Config.RSSHubEndpointStateless,
-		)
+	platformMeta, _ := commonPlatforms.Meta("tg_channel")
+	collectLink := platformMeta.FeedLink
 
 	if rawFeed, errCode, err := utils.RSSFeedRequestJson(
 		strings.ReplaceAll(collectLink, "{{username}}", username),
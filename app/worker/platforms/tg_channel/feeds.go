@@ -1,9 +1,7 @@
 package tg_channel
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"regexp"
@@ -24,35 +22,31 @@ func init() {
 	videoPosterRegex = regexp.MustCompile(`poster=["']([^"']+)["']`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://rsshub.app/telegram/channel/nya_sync_dev_test_c
 
-	// Concurrency control
-	cccs.Stateless.Request()
-	defer cccs.Stateless.Done()
-
 	global.Logger.Debug("New feeds request for telegram channel")
 
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateless}}",
-			config.Config.RSSHubEndpointStateless,
-		)
-
-	rawFeed, errCode, err := utils.RSSFeedRequestJson(
-		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
+	feedLink := strings.ReplaceAll(collectLink, "{{username}}", work.Username)
+	rawFeed, errCode, err := utils.RSSFeedRequestJsonConditional(
+		work,
+		feedLink,
 		true,
 	)
 	if err != nil {
 		return false, nil, errCode, err.Error()
 	}
 
+	// Fetch deeper if feeds might be missed
+	rawFeed = utils.RSSHubCollectDeeperJson(work, rawFeed, feedLink, true)
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.ID)
+
 		if item.DatePublished.After(work.DropBefore) && item.DatePublished.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				//Title:       item.Title,
@@ -101,8 +95,9 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 				medias = append(medias, video[1])
 
 				// Upload video poster
-				poster := videoPosterRegex.FindStringSubmatch(video[0])
-				medias = append(medias, poster[1])
+				if poster := videoPosterRegex.FindStringSubmatch(video[0]); len(poster) > 1 {
+					medias = append(medias, poster[1])
+				}
 			}
 
 			feed.Media = utils.UploadAllMedia(medias)
@@ -1,10 +1,9 @@
 package tiktok
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	"strings"
 )
 
@@ -15,14 +14,8 @@ func Account(username string, validateString string) (bool, uint, string, bool)
 	// RSSHub will index userinfo, so we need to parse their feeds
 	// Sorry TikTok server :pray:
 
-	collectLink := commonConsts.SUPPORTED_PLATFORM["tiktok"].FeedLink
-
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateless}}",
-			config.Config.RSSHubEndpointStateless,
-		)
+	platformMeta, _ := commonPlatforms.Meta("tiktok")
+	collectLink := platformMeta.FeedLink
 
 	if rawFeed, errCode, err := utils.RSSFeedRequest(
 		strings.ReplaceAll(collectLink, "{{username}}", username),
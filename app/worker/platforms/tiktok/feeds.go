@@ -1,9 +1,7 @@
 package tiktok
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
@@ -23,35 +21,36 @@ func init() {
 	videoRegex = regexp.MustCompile(`<source src="(.+?)"`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://rsshub.app/tiktok/user/@linustech
 
-	// Concurrency control
-	cccs.Stateless.Request()
-	defer cccs.Stateless.Done()
-
 	global.Logger.Debug("New feeds request for tiktok")
 
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateless}}",
-			config.Config.RSSHubEndpointStateless,
-		)
-
-	rawFeed, errCode, err := utils.RSSFeedRequest(
-		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
+	feedLink := strings.ReplaceAll(collectLink, "{{username}}", work.Username)
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(
+		work,
+		feedLink,
 		false,
 	)
 	if err != nil {
 		return false, nil, errCode, err.Error()
 	}
 
+	// Fetch deeper if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, utils.RSSHubDeepFetcher(feedLink, false))
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			// Unable to tell if collected before
+			continue
+		}
+
 		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Title: item.Title,
@@ -1,9 +1,7 @@
 package twitter
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
@@ -27,36 +25,32 @@ func init() {
 	endingSpacesRegex = regexp.MustCompile(`((<br\s*?/?>)|\s)+$`)
 }
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 	// Refer to https://rsshub.app/twitter/user/lc499
 
-	// Concurrency control
-	cccs.Stateless.Request()
-	defer cccs.Stateless.Done()
-
 	global.Logger.Debug("New feeds request for twitter")
 
-	collectLink =
-		strings.ReplaceAll(
-			collectLink,
-			"{{rsshub_stateless}}",
-			config.Config.RSSHubEndpointStateless,
-		)
-
 	// Different from common XML feeds, please ensure the link response format is JSON (see common/consts/platform.go).
-	rawFeed, errCode, err := utils.RSSFeedRequestJson(
-		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
+	feedLink := strings.ReplaceAll(collectLink, "{{username}}", work.Username)
+	rawFeed, errCode, err := utils.RSSFeedRequestJsonConditional(
+		work,
+		feedLink,
 		true,
 	)
 	if err != nil {
 		return false, nil, errCode, err.Error()
 	}
 
+	// Fetch deeper if feeds might be missed
+	rawFeed = utils.RSSHubCollectDeeperJson(work, rawFeed, feedLink, true)
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.ID)
+
 		if item.DatePublished.After(work.DropBefore) && item.DatePublished.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Link:        item.URL,
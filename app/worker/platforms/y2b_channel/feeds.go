@@ -2,26 +2,23 @@ package y2b_channel
 
 import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
 	"strings"
 )
 
-func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, collectLink string) (
+func Feeds(work *commonTypes.WorkDispatched, collectLink string) (
 	bool, []commonTypes.RawFeed, uint, string,
 ) {
 
 	// Refer to https://www.youtube.com/feeds/videos.xml?channel_id=UCs_f32UpeFk2CM8hzF7Q61Q
 
-	// Concurrency control
-	cccs.Direct.Request()
-	defer cccs.Direct.Done()
-
 	global.Logger.Debug("New feeds request for YouTube Channel")
 
-	rawFeed, errCode, err := utils.RSSFeedRequest(
+	rawFeed, errCode, err := utils.RSSFeedRequestConditional(
+		work,
 		strings.ReplaceAll(collectLink, "{{username}}", work.Username),
 		true,
 	)
@@ -29,13 +26,23 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 		return false, nil, errCode, err.Error()
 	}
 
+	// No pagination on source, just flag the gap if feeds might be missed
+	rawFeed.Items = utils.CollectDeeper(work, rawFeed.Items, nil)
+
 	var feeds []commonTypes.RawFeed
 
 	for _, item := range rawFeed.Items {
+		utils.ProcessingItem(work, item.GUID)
+
+		if item.PublishedParsed == nil {
+			// Unable to tell if collected before
+			continue
+		}
+
 		if item.PublishedParsed.After(work.DropBefore) && item.PublishedParsed.Before(work.DropAfter) {
 			feed := commonTypes.RawFeed{
 				Title:       item.Title,
-				Content:     item.Extensions["media"]["group"][0].Children["description"][0].Value,
+				Content:     mediaDescription(item),
 				Link:        item.Link,
 				GUID:        item.GUID,
 				Authors:     utils.ParseAuthors(item.Authors),
@@ -63,3 +70,16 @@ func Feeds(cccs *types.ConcurrencyChannels, work *commonTypes.WorkDispatched, co
 	return true, feeds, 0, ""
 
 }
+
+// mediaDescription : Description in media:group, or item description if not provided
+func mediaDescription(item *gofeed.Item) string {
+	groups := item.Extensions["media"]["group"]
+	if len(groups) == 0 {
+		return item.Description
+	}
+	descriptions := groups[0].Children["description"]
+	if len(descriptions) == 0 {
+		return item.Description
+	}
+	return descriptions[0].Value
+}
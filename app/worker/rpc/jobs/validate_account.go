@@ -3,18 +3,10 @@ package jobs
 import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/jike"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/mastodon"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/medium"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/pinterest"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/pixiv"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/substack"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/tg_channel"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/tiktok"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/twitter"
-	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms/y2b_channel"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/platforms"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"strings"
 )
@@ -29,42 +21,16 @@ func ValidateAccounts(validateReq *commonTypes.ValidateRequest, response *common
 
 	validateString := strings.ToLower(fmt.Sprintf("%s@Crossbell", handle))
 
-	var (
-		isSucceeded bool
-		code        uint
-		msg         string
-		isValid     bool
-	)
-
-	var accountValidateFunc func(string, string) (bool, uint, string, bool)
-
-	switch validateReq.Platform {
-	case "medium":
-		accountValidateFunc = medium.Account
-	case "tiktok":
-		accountValidateFunc = tiktok.Account
-	case "pinterest":
-		accountValidateFunc = pinterest.Account
-	case "twitter":
-		accountValidateFunc = twitter.Account
-	case "tg_channel":
-		accountValidateFunc = tg_channel.Account
-	case "substack":
-		accountValidateFunc = substack.Account
-	case "pixiv":
-		accountValidateFunc = pixiv.Account
-	case "y2b_channel":
-		accountValidateFunc = y2b_channel.Account
-	case "mastodon":
-		accountValidateFunc = mastodon.Account
-	case "jike":
-		accountValidateFunc = jike.Account
-	default:
+	collector, ok := platforms.Get(validateReq.Platform)
+	if !ok {
 		ValidateHandleFailed(commonConsts.ERROR_CODE_UNSUPPORTED_PLATFORM, "Unsupported platform", response)
 		return
+	} else if !commonPlatforms.IsEnabled(validateReq.Platform) {
+		ValidateHandleFailed(commonConsts.ERROR_CODE_PLATFORM_DISABLED, "Platform disabled", response)
+		return
 	}
 
-	isSucceeded, code, msg, isValid = accountValidateFunc(validateReq.Username, validateString)
+	isSucceeded, code, msg, isValid := collector.Account(validateReq.Username, validateString)
 
 	ValidateHandleResponse(isSucceeded, code, msg, isValid, response)
 
@@ -1,23 +1,78 @@
 package service
 
 import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/rpc/jobs"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/utils"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 )
 
+// WorkerRPC : Calls are tracked for graceful shutdown, accepted ones finish even if draining.
+// Panics in calls are reported in responses, instead of taking down the worker.
 type WorkerRPC struct{}
 
 func (rpc *WorkerRPC) Validate(request commonTypes.ValidateRequest, response *commonTypes.ValidateResponse) error {
+	shutdown.Track()
+	defer shutdown.Done()
+
+	defer func() {
+		if workPanic := recoverCall("Validate", "", recover()); workPanic != nil {
+			*response = commonTypes.ValidateResponse{
+				IsSucceeded: false,
+				Code:        commonConsts.ERROR_CODE_WORK_PANICKED,
+				Message:     workPanic.Error(),
+			}
+		}
+	}()
+
 	jobs.ValidateAccounts(&request, response)
 	return nil
 }
 
 func (rpc *WorkerRPC) OnChain(request commonTypes.OnChainRequest, response *commonTypes.OnChainResponse) error {
+	shutdown.Track()
+	defer shutdown.Done()
+
+	defer func() {
+		if workPanic := recoverCall("OnChain", request.GUID, recover()); workPanic != nil {
+			*response = commonTypes.OnChainResponse{
+				IsSucceeded: false,
+				Message:     workPanic.Error(),
+				Platform:    request.Platform,
+				FeedID:      request.FeedID,
+			}
+		}
+	}()
+
 	jobs.OnChainNotes(&request, response)
 	return nil
 }
 
 func (rpc *WorkerRPC) CheckOnChainData(request commonTypes.CheckOnChainDataRequest, response *commonTypes.CheckOnChainDataResponse) error {
+	shutdown.Track()
+	defer shutdown.Done()
+
+	defer func() {
+		if workPanic := recoverCall("CheckOnChainData", "", recover()); workPanic != nil {
+			*response = commonTypes.CheckOnChainDataResponse{
+				IsSucceeded: false,
+				Message:     workPanic.Error(),
+			}
+		}
+	}()
+
 	jobs.CheckOnChainData(&request, response)
 	return nil
 }
+
+// recoverCall : Call with recover() in deferred function, nil if not panicked
+func recoverCall(method string, itemID string, recovered interface{}) *utils.WorkPanic {
+	workPanic := utils.RecoverPanic(recovered)
+	if workPanic != nil {
+		workPanic.ItemID = itemID
+		global.Logger.Errorf("RPC call %s %s\n%s", method, workPanic.Error(), workPanic.Stack)
+	}
+	return workPanic
+}
@@ -0,0 +1,136 @@
+package rsshub
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"sync"
+	"time"
+)
+
+type endpoint struct {
+	url string
+
+	successRate float64 // Moving average, 0 ~ 1
+	latency     float64 // Moving average, in milliseconds
+
+	requests  uint64
+	failures  uint64
+	lastError string
+
+	consecutiveFailures int
+	cooldownUntil       time.Time
+}
+
+// Pool : RSSHub instances of the same class, scored by success rate and latency
+type Pool struct {
+	lock      sync.Mutex
+	endpoints []*endpoint
+}
+
+func NewPool(urls []string) *Pool {
+	p := &Pool{}
+	for _, url := range urls {
+		p.endpoints = append(p.endpoints, &endpoint{
+			url:         url,
+			successRate: 1, // Trust at beginning
+		})
+	}
+	return p
+}
+
+func (p *Pool) Size() int {
+	return len(p.endpoints)
+}
+
+// score : Higher is better, negative if cooling down
+func (e *endpoint) score(now time.Time) float64 {
+	if now.Before(e.cooldownUntil) {
+		return -float64(e.cooldownUntil.Sub(now)) // Earlier recovery first
+	}
+	return e.successRate / (1 + e.latency/1000)
+}
+
+// Pick : Healthiest endpoint except tried ones, empty if none left
+func (p *Pool) Pick(tried map[string]bool) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+
+	var best *endpoint
+	bestScore := 0.0
+	for _, e := range p.endpoints {
+		if tried[e.url] {
+			continue
+		}
+		s := e.score(now)
+		if best == nil || s > bestScore || (s == bestScore && e.requests < best.requests) {
+			best, bestScore = e, s
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+	return best.url
+}
+
+// Report : Update health of endpoint with request result
+func (p *Pool) Report(url string, latency time.Duration, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, e := range p.endpoints {
+		if e.url != url {
+			continue
+		}
+
+		e.requests++
+		e.latency = e.latency*(1-consts.RSSHUB_HEALTH_DECAY) + float64(latency.Milliseconds())*consts.RSSHUB_HEALTH_DECAY
+
+		if err == nil {
+			e.successRate = e.successRate*(1-consts.RSSHUB_HEALTH_DECAY) + consts.RSSHUB_HEALTH_DECAY
+			e.consecutiveFailures = 0
+			e.cooldownUntil = time.Time{}
+		} else {
+			e.successRate = e.successRate * (1 - consts.RSSHUB_HEALTH_DECAY)
+			e.failures++
+			e.lastError = err.Error()
+			e.consecutiveFailures++
+			if e.consecutiveFailures >= consts.RSSHUB_COOLDOWN_AFTER_FAILURES {
+				cooldown := consts.RSSHUB_COOLDOWN_BASE << (e.consecutiveFailures - consts.RSSHUB_COOLDOWN_AFTER_FAILURES)
+				if cooldown > consts.RSSHUB_COOLDOWN_MAX || cooldown <= 0 {
+					cooldown = consts.RSSHUB_COOLDOWN_MAX
+				}
+				e.cooldownUntil = time.Now().Add(cooldown)
+			}
+		}
+
+		return
+	}
+}
+
+// Stats : Current status of all endpoints
+func (p *Pool) Stats() []commonTypes.EndpointStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+
+	stats := make([]commonTypes.EndpointStats, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		stats = append(stats, commonTypes.EndpointStats{
+			Endpoint:     e.url,
+			Healthy:      !now.Before(e.cooldownUntil),
+			Score:        e.score(now),
+			SuccessRate:  e.successRate,
+			LatencyMs:    e.latency,
+			Requests:     e.requests,
+			Failures:     e.failures,
+			LastError:    e.lastError,
+			CooldownTill: e.cooldownUntil,
+		})
+	}
+
+	return stats
+}
@@ -0,0 +1,75 @@
+package rsshub
+
+import (
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"strings"
+	"time"
+)
+
+const (
+	PlaceholderStateful  = "{{rsshub_stateful}}"
+	PlaceholderStateless = "{{rsshub_stateless}}"
+)
+
+var (
+	Stateful  = NewPool(nil)
+	Stateless = NewPool(nil)
+)
+
+// Init : Prepare pools with endpoints
+func Init(stateful []string, stateless []string) {
+	Stateful = NewPool(stateful)
+	Stateless = NewPool(stateless)
+}
+
+// Do : Replace RSSHub placeholder in link with the healthiest endpoint, and fail over to others if unavailable.
+// Links without placeholders are requested as is.
+func Do(link string, request func(link string) error) error {
+	var (
+		pool        *Pool
+		placeholder string
+	)
+	if strings.Contains(link, PlaceholderStateful) {
+		pool, placeholder = Stateful, PlaceholderStateful
+	} else if strings.Contains(link, PlaceholderStateless) {
+		pool, placeholder = Stateless, PlaceholderStateless
+	} else {
+		return request(link)
+	}
+
+	if pool.Size() == 0 {
+		return errors.New("no RSSHub endpoint configured")
+	}
+
+	tried := make(map[string]bool)
+	var err error
+	for endpoint := pool.Pick(tried); endpoint != ""; endpoint = pool.Pick(tried) {
+		tried[endpoint] = true
+
+		startAt := time.Now()
+		err = request(strings.ReplaceAll(link, placeholder, endpoint))
+		if !isEndpointFault(err) {
+			pool.Report(endpoint, time.Since(startAt), nil)
+			return err
+		}
+		pool.Report(endpoint, time.Since(startAt), err)
+	}
+
+	return err
+}
+
+// isEndpointFault : Should fail over to another endpoint.
+// Errors of route (like 404 or 500 for a deleted user) would be the same on other endpoints, so never counted.
+func isEndpointFault(err error) bool {
+	return httpclient.IsUnavailable(err)
+}
+
+// Stats : Status of endpoints by class
+func Stats() map[string][]commonTypes.EndpointStats {
+	return map[string][]commonTypes.EndpointStats{
+		"stateful":  Stateful.Stats(),
+		"stateless": Stateless.Stats(),
+	}
+}
@@ -0,0 +1,129 @@
+package rsshub
+
+import (
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func get(link string) error {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	_, err = httpclient.Do(req, false)
+	return err
+}
+
+func TestDo(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer down.Close()
+
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/twitter/user/test" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer up.Close()
+
+	// Our own services
+	httpclient.TrustHost(down.URL)
+	httpclient.TrustHost(up.URL)
+
+	Init(nil, []string{down.URL, up.URL})
+
+	for i := 0; i < 5; i++ {
+		if err := Do(PlaceholderStateless+"/twitter/user/test", get); err != nil {
+			t.Fatalf("should fail over to healthy endpoint, got %v", err)
+		}
+	}
+
+	// Route not found is not endpoint's fault
+	if err := Do(PlaceholderStateless+"/twitter/user/missing", get); !httpclient.IsStatus(err, http.StatusNotFound) {
+		t.Fatalf("should be not found, got %v", err)
+	}
+
+	// Tried once only, then scored lower
+	stats := Stats()["stateless"]
+	if stats[0].Requests != 1 || stats[0].Failures != 1 || stats[0].Score >= stats[1].Score {
+		t.Errorf("%s should be scored lower: %+v", down.URL, stats[0])
+	}
+	if stats[1].Requests != 6 || stats[1].Failures != 0 {
+		t.Errorf("%s should be healthy: %+v", up.URL, stats[1])
+	}
+	if Stateless.Pick(nil) != up.URL {
+		t.Errorf("should pick %s", up.URL)
+	}
+}
+
+func TestDoRouteError(t *testing.T) {
+	config.Config.HttpRetries = 2
+
+	// Like a deleted user, broken on every endpoint
+	requests := 0
+	broken := func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	first := httptest.NewServer(http.HandlerFunc(broken))
+	defer first.Close()
+	second := httptest.NewServer(http.HandlerFunc(broken))
+	defer second.Close()
+	httpclient.TrustHost(first.URL)
+	httpclient.TrustHost(second.URL)
+
+	Init(nil, []string{first.URL, second.URL})
+
+	for i := 0; i < 5; i++ {
+		if err := Do(PlaceholderStateless+"/twitter/user/deleted", get); !httpclient.IsStatus(err, http.StatusInternalServerError) {
+			t.Fatalf("should fail with route error, got %v", err)
+		}
+	}
+
+	if requests != 5 {
+		t.Errorf("route error should neither be retried nor failed over, got %d requests", requests)
+	}
+	for _, stats := range Stats()["stateless"] {
+		if stats.Failures != 0 || !stats.Healthy || stats.SuccessRate < 1 {
+			t.Errorf("%s should not be penalised for route error: %+v", stats.Endpoint, stats)
+		}
+	}
+}
+
+func TestPoolCooldown(t *testing.T) {
+	p := NewPool([]string{"a", "b"})
+
+	for i := 0; i < 3; i++ {
+		p.Report("a", 0, errors.New("unavailable"))
+	}
+	p.Report("b", 10*time.Second, errors.New("unavailable"))
+
+	// Slow and failing, but not cooling down
+	if p.Pick(nil) != "b" {
+		t.Error("should pick endpoint not cooling down")
+	}
+	if p.Stats()[0].Healthy {
+		t.Error("should be cooling down")
+	}
+
+	// Still usable when nothing else left
+	if p.Pick(map[string]bool{"b": true}) != "a" {
+		t.Error("should pick endpoint cooling down when no others")
+	}
+	if p.Pick(map[string]bool{"a": true, "b": true}) != "" {
+		t.Error("should pick nothing when all tried")
+	}
+
+	p.Report("a", 0, nil)
+	if !p.Stats()[0].Healthy {
+		t.Error("should recover after success")
+	}
+}
@@ -1,7 +0,0 @@
-package types
-
-type ConcurrencyChannels struct {
-	Stateful  *ConcurrencyControl
-	Stateless *ConcurrencyControl
-	Direct    *ConcurrencyControl
-}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/rsshub"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"net/http"
+	"sync"
+)
+
+var ErrNotModified = errors.New("not modified since last request")
+
+type httpValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// Validators of works in progress, only saved when work succeeded,
+// so feeds won't be missed if this work failed after fetching.
+var pendingValidators sync.Map // *commonTypes.WorkDispatched : map[string]httpValidators (url : validators)
+
+// ConditionalHttpRequest : Request with cached ETag / Last-Modified of the url, returns ErrNotModified on 304
+func ConditionalHttpRequest(work *commonTypes.WorkDispatched, url string, withProxy bool) ([]byte, error) {
+	// Cached with RSSHub placeholders, so validators are kept when switched to another endpoint
+	cached := loadHttpValidators(url)
+
+	var (
+		body       []byte
+		validators *httpValidators
+	)
+	err := rsshub.Do(url, func(link string) error {
+		var err error
+		body, validators, err = conditionalHttpRequest(link, withProxy, cached)
+		return err
+	})
+	if httpclient.IsStatus(err, http.StatusNotModified) {
+		return nil, ErrNotModified
+	} else if err != nil {
+		return nil, err
+	}
+
+	if validators != nil {
+		v, _ := pendingValidators.LoadOrStore(work, make(map[string]httpValidators))
+		v.(map[string]httpValidators)[url] = *validators
+	}
+
+	return body, nil
+}
+
+func conditionalHttpRequest(url string, withProxy bool, cached *httpValidators) ([]byte, *httpValidators, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	res, err := httpclient.Do(req, withProxy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := res.Body
+	if res.StatusCode != http.StatusOK {
+		// Never cache partial responses
+		return body, nil, nil
+	}
+
+	validators := httpValidators{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+	if validators.ETag == "" && validators.LastModified == "" {
+		return body, nil, nil
+	}
+
+	return body, &validators, nil
+}
+
+// CommitHttpValidators : Save validators of a succeeded work for next requests
+func CommitHttpValidators(work *commonTypes.WorkDispatched) {
+	v, ok := pendingValidators.LoadAndDelete(work)
+	if !ok || commonGlobal.Redis == nil {
+		return
+	}
+
+	for url, validators := range v.(map[string]httpValidators) {
+		validatorsBytes, err := json.Marshal(&validators)
+		if err != nil {
+			continue
+		}
+		key := fmt.Sprintf(commonConsts.REDIS_HttpValidatorsKeyTemplate, url)
+		if err := commonGlobal.Redis.Set(context.Background(), key, validatorsBytes, commonConsts.REDIS_HttpValidatorsExpires).Err(); err != nil {
+			global.Logger.Warnf("Failed to save http validators of %s with error: %s", url, err.Error())
+		}
+	}
+}
+
+// DiscardHttpValidators : Drop validators of a failed work, so contents will be fetched again next time
+func DiscardHttpValidators(work *commonTypes.WorkDispatched) {
+	pendingValidators.Delete(work)
+}
+
+func loadHttpValidators(url string) *httpValidators {
+	if commonGlobal.Redis == nil {
+		return nil
+	}
+
+	validatorsBytes, err := commonGlobal.Redis.Get(context.Background(), fmt.Sprintf(commonConsts.REDIS_HttpValidatorsKeyTemplate, url)).Bytes()
+	if err != nil {
+		// Not found or unavailable, just request without conditions
+		return nil
+	}
+
+	var validators httpValidators
+	if err := json.Unmarshal(validatorsBytes, &validators); err != nil {
+		return nil
+	}
+
+	return &validators
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConditionalHttpRequest(t *testing.T) {
+	const etag = `"v1"`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("feeds"))
+	}))
+	defer server.Close()
+	httpclient.TrustHost(server.URL)
+
+	body, validators, err := conditionalHttpRequest(server.URL, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "feeds" || validators == nil || validators.ETag != etag {
+		t.Fatalf("unexpected response: %s, %v", body, validators)
+	}
+
+	if _, _, err := conditionalHttpRequest(server.URL, false, validators); !httpclient.IsStatus(err, http.StatusNotModified) {
+		t.Fatalf("should be not modified, got %v", err)
+	}
+
+	// Validators are pending until work succeeded
+	work := &commonTypes.WorkDispatched{}
+	if _, err := ConditionalHttpRequest(work, server.URL, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := pendingValidators.Load(work); !ok {
+		t.Fatal("validators should be pending")
+	}
+	DiscardHttpValidators(work)
+	if _, ok := pendingValidators.Load(work); ok {
+		t.Fatal("validators should be discarded")
+	}
+}
@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
+	"sync"
+	"time"
+)
+
+// Gaps of works in progress, reported along with succeeded results
+var pendingGaps sync.Map // *commonTypes.WorkDispatched : commonTypes.FeedGap
+
+// FeedPageFetcher : Fetch items older than oldest, exhausted if nothing older exists on the source
+type FeedPageFetcher func(page int, oldest *gofeed.Item) (items []*gofeed.Item, exhausted bool, err error)
+
+// ItemTime : Published time of item, or updated time if not provided
+func ItemTime(item *gofeed.Item) *time.Time {
+	if item.PublishedParsed != nil {
+		return item.PublishedParsed
+	}
+	return item.UpdatedParsed
+}
+
+// HasFeedGap : Oldest item still newer than DropBefore, so feeds in between might be missed.
+// Works collecting all history (DropBefore not set) never have gaps.
+func HasFeedGap(work *commonTypes.WorkDispatched, oldest time.Time) bool {
+	return work.DropBefore.After(time.Unix(0, 0)) && oldest.After(work.DropBefore)
+}
+
+// CollectDeeper : Fetch deeper pages with fetcher (if any) until DropBefore reached,
+// returns all items merged, and records the gap if still unresolved.
+func CollectDeeper(work *commonTypes.WorkDispatched, items []*gofeed.Item, fetcher FeedPageFetcher) []*gofeed.Item {
+	oldest := oldestItem(items)
+	if oldest == nil || !HasFeedGap(work, *ItemTime(oldest)) {
+		return items
+	}
+
+	if fetcher != nil {
+		known := make(map[string]bool, len(items))
+		for _, item := range items {
+			known[itemKey(item)] = true
+		}
+
+		for page := 2; page < 2+consts.DEEP_FETCH_MAX_PAGES; page++ {
+			more, exhausted, err := fetcher(page, oldest)
+			if err != nil {
+				global.Logger.Warnf("Failed to fetch page %d of %s (%s) with error: %s", page, work.Username, work.Platform, err.Error())
+				break
+			}
+
+			added := 0
+			for _, item := range more {
+				if ItemTime(item) == nil || known[itemKey(item)] {
+					continue
+				}
+				known[itemKey(item)] = true
+				items = append(items, item)
+				added++
+			}
+
+			oldest = oldestItem(items)
+			if exhausted || !HasFeedGap(work, *ItemTime(oldest)) {
+				// Nothing missed
+				return items
+			} else if added == 0 {
+				// Source can't go deeper
+				break
+			}
+		}
+	}
+
+	MarkFeedGap(work, *ItemTime(oldest))
+
+	return items
+}
+
+// MarkFeedGap : Record feeds between DropBefore and oldest might be missed
+func MarkFeedGap(work *commonTypes.WorkDispatched, oldest time.Time) {
+	global.Logger.Warnf("Feeds of %s (%s) between %s and %s might be missed", work.Username, work.Platform, work.DropBefore.Format(time.RFC3339), oldest.Format(time.RFC3339))
+
+	pendingGaps.Store(work, commonTypes.FeedGap{
+		From: work.DropBefore,
+		To:   oldest,
+	})
+}
+
+// TakeFeedGap : Unresolved gap of a succeeded work, nil if nothing missed
+func TakeFeedGap(work *commonTypes.WorkDispatched) *commonTypes.FeedGap {
+	gap, ok := pendingGaps.LoadAndDelete(work)
+	if !ok {
+		return nil
+	}
+
+	feedGap := gap.(commonTypes.FeedGap)
+	return &feedGap
+}
+
+// DiscardFeedGap : Drop gap of a failed work, it will be detected again next time
+func DiscardFeedGap(work *commonTypes.WorkDispatched) {
+	pendingGaps.Delete(work)
+}
+
+func oldestItem(items []*gofeed.Item) *gofeed.Item {
+	var oldest *gofeed.Item
+	for _, item := range items {
+		t := ItemTime(item)
+		if t == nil {
+			continue
+		}
+		if oldest == nil || t.Before(*ItemTime(oldest)) {
+			oldest = item
+		}
+	}
+	return oldest
+}
+
+func itemKey(item *gofeed.Item) string {
+	if item.GUID != "" {
+		return item.GUID
+	}
+	return item.Link
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+func TestCollectDeeper(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	global.Logger = logger.Sugar()
+
+	now := time.Now()
+	itemsOf := func(from int, to int) []*gofeed.Item {
+		var items []*gofeed.Item
+		for i := from; i < to; i++ {
+			publishedAt := now.Add(-time.Duration(i) * time.Hour)
+			items = append(items, &gofeed.Item{GUID: fmt.Sprint(i), PublishedParsed: &publishedAt})
+		}
+		return items
+	}
+	work := &commonTypes.WorkDispatched{DropBefore: now.Add(-25 * time.Hour)}
+
+	// Resolved by deeper pages
+	items := CollectDeeper(work, itemsOf(0, 10), func(page int, _ *gofeed.Item) ([]*gofeed.Item, bool, error) {
+		return itemsOf((page-1)*10, page*10), false, nil
+	})
+	if len(items) != 30 {
+		t.Errorf("should fetch until DropBefore, got %d items", len(items))
+	}
+	if gap := TakeFeedGap(work); gap != nil {
+		t.Errorf("gap should be resolved, got %v", gap)
+	}
+
+	// Source can't go deeper
+	items = CollectDeeper(work, itemsOf(0, 10), func(_ int, _ *gofeed.Item) ([]*gofeed.Item, bool, error) {
+		return itemsOf(0, 10), false, nil
+	})
+	if len(items) != 10 {
+		t.Errorf("duplicated items should be skipped, got %d items", len(items))
+	}
+	if gap := TakeFeedGap(work); gap == nil || !gap.To.Equal(*items[9].PublishedParsed) {
+		t.Errorf("gap should be recorded until oldest item, got %v", gap)
+	}
+
+	// History exhausted
+	CollectDeeper(work, itemsOf(0, 10), func(_ int, _ *gofeed.Item) ([]*gofeed.Item, bool, error) {
+		return nil, true, nil
+	})
+	if gap := TakeFeedGap(work); gap != nil {
+		t.Errorf("no gap if nothing older, got %v", gap)
+	}
+
+	// Collecting all history
+	allWork := &commonTypes.WorkDispatched{DropBefore: time.Unix(0, 0)}
+	CollectDeeper(allWork, itemsOf(0, 10), nil)
+	if gap := TakeFeedGap(allWork); gap != nil {
+		t.Errorf("no gap without DropBefore, got %v", gap)
+	}
+}
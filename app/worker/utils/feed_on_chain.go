@@ -7,7 +7,7 @@ import (
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/indexer"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/types"
-	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonPlatforms "github.com/Crossbell-Box/OperatorSync/common/platforms"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	md "github.com/JohannesKaufmann/html-to-markdown"
 	"strconv"
@@ -37,7 +37,7 @@ func FeedOnChain(work *commonTypes.OnChainRequest) (string, string, int64, int64
 	}
 
 	// Step 0: Prepare platform
-	platform := commonConsts.SUPPORTED_PLATFORM[work.Platform]
+	platform, _ := commonPlatforms.Meta(work.Platform)
 
 	// Step 1: Parse feeds to note metadata
 	metadata := types.NoteMetadata{
@@ -74,6 +74,14 @@ func FeedOnChain(work *commonTypes.OnChainRequest) (string, string, int64, int64
 		metadata.ExternalUrls = []string{work.Link}
 	}
 
+	for _, attribute := range work.Attributes {
+		metadata.Attributes = append(metadata.Attributes, types.NoteAttribute{
+			Value:       attribute.Value,
+			TraitType:   attribute.TraitType,
+			DisplayType: attribute.DisplayType,
+		})
+	}
+
 	if platform.IsMediaAttachments {
 		for _, media := range work.Media {
 			// Append basic info
@@ -92,6 +100,10 @@ func FeedOnChain(work *commonTypes.OnChainRequest) (string, string, int64, int64
 			if err != nil {
 				global.Logger.Errorf("Failed to parse additional props for media #%s with error: %s", media.IPFSUri, err.Error())
 			} else {
+				// If has "alt" (like image description provided by author)
+				if mediaAlt, ok := additionalProps["alt"]; ok && mediaAlt != "" {
+					attachment.Alt = StringPointerOmitEmpty(mediaAlt)
+				}
 				// If has "width" and "height"
 				if mediaWidthStr, ok := additionalProps["width"]; ok {
 					mediaWidth, err := strconv.Atoi(mediaWidthStr)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
 	"net/http"
 )
 
@@ -18,14 +19,18 @@ func GetCrossbellHandleFromID(characterID string) (string, error) {
 	// With handle https://indexer.crossbell.io/v1/handles/candinya/character
 
 	reqUrl := fmt.Sprintf("https://indexer.crossbell.io/v1/characters/%s", characterID)
-	rawRes, err := (&http.Client{}).Get(reqUrl)
+	req, err := http.NewRequest(http.MethodGet, reqUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	rawRes, err := httpclient.Do(req, false)
 	if err != nil {
 		global.Logger.Error("Failed to get character info for ", characterID, " : ", err.Error())
 		return "", err
 	}
 
 	var crossbellIndexerResponse crossbellIndexerCharacterRes
-	if err = json.NewDecoder(rawRes.Body).Decode(&crossbellIndexerResponse); err != nil {
+	if err = json.Unmarshal(rawRes.Body, &crossbellIndexerResponse); err != nil {
 		global.Logger.Error("Failed to parse response data: ", err.Error())
 		return "", err
 	}
@@ -1,33 +1,45 @@
 package utils
 
 import (
-	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
-	"io"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/rsshub"
 	"net/http"
 )
 
 func HttpRequest(url string, withProxy bool) ([]byte, error) {
 
-	var tr http.Transport
-	if withProxy && config.Config.ProxyURL != nil {
-		tr.Proxy = http.ProxyURL(config.Config.ProxyURL)
-	}
+	var body []byte
 
-	client := &http.Client{
-		Transport: &tr,
-	}
+	// RSSHub placeholders are replaced with healthy endpoints
+	err := rsshub.Do(url, func(link string) error {
+		req, err := http.NewRequest(http.MethodGet, link, nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := httpclient.Do(req, withProxy)
+		if err != nil {
+			return err
+		}
+
+		body = res.Body
+		return nil
+	})
 
-	res, err := client.Get(url)
+	return body, err
+}
+
+// MediaRequest : Download media with media limits, which is much larger than feeds
+func MediaRequest(url string, withProxy bool) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := io.ReadAll(res.Body)
+	res, err := httpclient.DoMedia(req, withProxy)
 	if err != nil {
 		return nil, err
 	}
 
-	_ = res.Body.Close() // Ignore error
-
-	return body, nil
+	return res.Body, nil
 }
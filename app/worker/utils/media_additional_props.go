@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+)
+
+// SetMediaAdditionalProp : Set a key in media's JSON-stringified additional props (like "alt")
+func SetMediaAdditionalProp(media *types.Media, key string, value string) {
+	props := make(map[string]interface{})
+	if media.AdditionalProps != "" {
+		_ = json.Unmarshal([]byte(media.AdditionalProps), &props) // Start from empty if invalid
+	}
+
+	props[key] = value
+
+	if propsBytes, err := json.Marshal(props); err == nil {
+		media.AdditionalProps = string(propsBytes)
+	}
+}
@@ -2,7 +2,9 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
 	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
 	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
 	"github.com/mmcdole/gofeed"
@@ -11,9 +13,25 @@ import (
 func RSSFeedRequest(url string, withProxy bool) (*gofeed.Feed, uint, error) {
 	feedsBody, err := HttpRequest(url, withProxy)
 	if err != nil {
-		return nil, commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, err
+		return nil, httpclient.ErrorCode(err), err
 	}
 
+	return parseRSSFeed(feedsBody)
+}
+
+// RSSFeedRequestConditional : Same as RSSFeedRequest, but skip unchanged feeds (with ERROR_CODE_NOT_MODIFIED)
+func RSSFeedRequestConditional(work *commonTypes.WorkDispatched, url string, withProxy bool) (*gofeed.Feed, uint, error) {
+	feedsBody, err := ConditionalHttpRequest(work, url, withProxy)
+	if errors.Is(err, ErrNotModified) {
+		return nil, commonConsts.ERROR_CODE_NOT_MODIFIED, err
+	} else if err != nil {
+		return nil, httpclient.ErrorCode(err), err
+	}
+
+	return parseRSSFeed(feedsBody)
+}
+
+func parseRSSFeed(feedsBody []byte) (*gofeed.Feed, uint, error) {
 	fp := gofeed.NewParser()
 	feed, err := fp.ParseString(string(feedsBody))
 	if err != nil {
@@ -26,12 +44,28 @@ func RSSFeedRequest(url string, withProxy bool) (*gofeed.Feed, uint, error) {
 func RSSFeedRequestJson(url string, withProxy bool) (*commonTypes.FeedWithExtra, uint, error) {
 	feedsBody, err := HttpRequest(url, withProxy)
 	if err != nil {
-		return nil, commonConsts.ERROR_CODE_HTTP_REQUEST_FAILED, err
+		return nil, httpclient.ErrorCode(err), err
 	}
 
+	return parseRSSFeedJson(feedsBody)
+}
+
+// RSSFeedRequestJsonConditional : Same as RSSFeedRequestJson, but skip unchanged feeds (with ERROR_CODE_NOT_MODIFIED)
+func RSSFeedRequestJsonConditional(work *commonTypes.WorkDispatched, url string, withProxy bool) (*commonTypes.FeedWithExtra, uint, error) {
+	feedsBody, err := ConditionalHttpRequest(work, url, withProxy)
+	if errors.Is(err, ErrNotModified) {
+		return nil, commonConsts.ERROR_CODE_NOT_MODIFIED, err
+	} else if err != nil {
+		return nil, httpclient.ErrorCode(err), err
+	}
+
+	return parseRSSFeedJson(feedsBody)
+}
+
+func parseRSSFeedJson(feedsBody []byte) (*commonTypes.FeedWithExtra, uint, error) {
 	var feed commonTypes.FeedWithExtra
 
-	err = json.Unmarshal(feedsBody, &feed)
+	err := json.Unmarshal(feedsBody, &feed)
 	if err != nil {
 		global.Logger.Errorf("Failed to parse response %s as json with error: %v", feedsBody, err)
 		return nil, commonConsts.ERROR_CODE_FAILED_TO_PARSE_FEEDS, err
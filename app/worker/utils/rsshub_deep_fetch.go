@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/consts"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/mmcdole/gofeed"
+	"strings"
+	"time"
+)
+
+// RSSHubDeepFetcher : RSSHub has no pagination, so request once more with a larger limit
+func RSSHubDeepFetcher(url string, withProxy bool) FeedPageFetcher {
+	return func(page int, _ *gofeed.Item) ([]*gofeed.Item, bool, error) {
+		if page > 2 {
+			// Already requested with the largest limit
+			return nil, false, nil
+		}
+
+		rawFeed, _, err := RSSFeedRequest(rsshubLimitedUrl(url), withProxy)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return rawFeed.Items, len(rawFeed.Items) < consts.RSSHUB_DEEP_FETCH_LIMIT, nil
+	}
+}
+
+// RSSHubCollectDeeperJson : Same as CollectDeeper with RSSHubDeepFetcher, but for JSON feeds
+func RSSHubCollectDeeperJson(work *commonTypes.WorkDispatched, rawFeed *commonTypes.FeedWithExtra, url string, withProxy bool) *commonTypes.FeedWithExtra {
+	oldest, ok := oldestItemJson(rawFeed.Items)
+	if !ok || !HasFeedGap(work, oldest) {
+		return rawFeed
+	}
+
+	deeperFeed, _, err := RSSFeedRequestJson(rsshubLimitedUrl(url), withProxy)
+	if err != nil {
+		global.Logger.Warnf("Failed to fetch deeper feeds of %s (%s) with error: %s", work.Username, work.Platform, err.Error())
+	} else if len(deeperFeed.Items) > len(rawFeed.Items) {
+		// Latest items are included as well
+		rawFeed = deeperFeed
+		oldest, _ = oldestItemJson(rawFeed.Items)
+
+		if !HasFeedGap(work, oldest) || len(rawFeed.Items) < consts.RSSHUB_DEEP_FETCH_LIMIT {
+			return rawFeed
+		}
+	}
+
+	MarkFeedGap(work, oldest)
+
+	return rawFeed
+}
+
+func rsshubLimitedUrl(url string) string {
+	if strings.Contains(url, "?") {
+		return fmt.Sprintf("%s&limit=%d", url, consts.RSSHUB_DEEP_FETCH_LIMIT)
+	}
+	return fmt.Sprintf("%s?limit=%d", url, consts.RSSHUB_DEEP_FETCH_LIMIT)
+}
+
+func oldestItemJson(items []*commonTypes.ItemWithExtra) (time.Time, bool) {
+	var oldest time.Time
+	for _, item := range items {
+		if item.DatePublished.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || item.DatePublished.Before(oldest) {
+			oldest = item.DatePublished
+		}
+	}
+	return oldest, !oldest.IsZero()
+}
@@ -24,13 +24,20 @@ func UploadAllMedia(mediaUris []string) []types.Media {
 		innerUri := uri
 		ipfsUploadWg.Add(1)
 		go func() {
+			defer ipfsUploadWg.Done()
+			defer func() {
+				// Out of work goroutine, recover here or the worker goes down
+				if workPanic := RecoverPanic(recover()); workPanic != nil {
+					global.Logger.Errorf("Failed to upload link (%s) onto IPFS: %s\n%s", innerUri, workPanic.Error(), workPanic.Stack)
+				}
+			}()
+
 			media, err := UploadOneMedia(innerUri)
 			if err != nil {
 				global.Logger.Error("Failed to upload link (", innerUri, ") onto IPFS: ", err.Error())
 			} else {
 				ipfsUploadResultChannel <- *media
 			}
-			ipfsUploadWg.Done()
 		}()
 	}
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/config"
 	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/httpclient"
 	"image"
 	_ "image/gif"  // Add GIF support
 	_ "image/jpeg" // Add JPEG support
@@ -44,7 +45,7 @@ func UploadURLToIPFS(targetUrl string, withProxy bool) (string, string, uint, st
 	filename := path.Base(reqUrl.Path)
 
 	// Retrieve data
-	body, err := HttpRequest(targetUrl, withProxy)
+	body, err := MediaRequest(targetUrl, withProxy)
 	if err != nil {
 		global.Logger.Error("Failed to retrieve data from: ", targetUrl)
 		return "", "", 0, "", "", err
@@ -117,12 +118,12 @@ func UploadBytesToIPFS(data []byte, filename string) (string, uint, error) {
 		return "", 0, err
 	}
 	ipfsReq.Header.Set("Content-Type", formContentType)
-	ipfsRes, err := (&http.Client{}).Do(ipfsReq)
+	ipfsRes, err := httpclient.DoMedia(ipfsReq, false)
 	if err != nil {
 		global.Logger.Error("Failed to do request: ", err.Error())
 		return "", 0, err
 	}
-	err = json.NewDecoder(ipfsRes.Body).Decode(&resp)
+	err = json.Unmarshal(ipfsRes.Body, &resp)
 	if err != nil {
 		log.Println("Error decoding JSON:", err)
 		return "", 0, err
@@ -154,12 +155,12 @@ func UploadVideoToIPFS(videoUrl string) (string, uint, error) {
 		// Do request
 		global.Logger.Debugf("Checking upload status for %s", videoUrl)
 		var resp response
-		ipfsRes, err := (&http.Client{}).Do(ipfsReq)
+		ipfsRes, err := httpclient.Do(ipfsReq, false)
 		if err != nil {
 			global.Logger.Error("Failed to do request: ", err.Error())
 			return "", 0, err
 		}
-		err = json.NewDecoder(ipfsRes.Body).Decode(&resp)
+		err = json.Unmarshal(ipfsRes.Body, &resp)
 		if err != nil {
 			log.Println("Error decoding JSON:", err)
 			return "", 0, err
@@ -1,6 +1,9 @@
 package utils
 
-import "net/url"
+import (
+	"fmt"
+	"net/url"
+)
 
 func ValidateUri(uri string) bool {
 	if uri == "" {
@@ -12,3 +15,15 @@ func ValidateUri(uri string) bool {
 	return err == nil
 
 }
+
+// ParseHttpUri : Only accept absolute http(s) URIs, like feed URL provided as username
+func ParseHttpUri(uri string) (string, error) {
+	parsedUri, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %v", err)
+	}
+	if (parsedUri.Scheme != "http" && parsedUri.Scheme != "https") || parsedUri.Host == "" {
+		return "", fmt.Errorf("invalid URL: %s", uri)
+	}
+	return parsedUri.String(), nil
+}
@@ -13,3 +13,16 @@ func TestValidateUri(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestParseHttpUri(t *testing.T) {
+	for _, valid := range []string{"https://blog.example.com/feed.xml", "http://example.com/index.json"} {
+		if _, err := ParseHttpUri(valid); err != nil {
+			t.Errorf("%s should be valid: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"blog.example.com/feed.xml", "file:///etc/passwd", ""} {
+		if _, err := ParseHttpUri(invalid); err == nil {
+			t.Errorf("%s should be invalid", invalid)
+		}
+	}
+}
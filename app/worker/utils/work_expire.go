@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonConsts "github.com/Crossbell-Box/OperatorSync/common/consts"
+	commonGlobal "github.com/Crossbell-Box/OperatorSync/common/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// acceptWorkScript : Record dispatch time of the latest work for account, returns 1 if a newer one already accepted
+var acceptWorkScript = redis.NewScript(`
+local latest = tonumber(redis.call("GET", KEYS[1]) or "0")
+if tonumber(ARGV[1]) < latest then
+	return 1
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 0
+`)
+
+// IsWorkExpired : Work past DropAfter, or superseded by a newer work for the same account
+func IsWorkExpired(work *commonTypes.WorkDispatched) (string, bool) {
+	if !work.DropAfter.IsZero() && time.Now().After(work.DropAfter) {
+		return fmt.Sprintf("Work expired at %s", work.DropAfter.Format(time.RFC3339)), true
+	}
+
+	if superseded, err := isWorkSuperseded(work); err != nil {
+		// Not sure, just process it
+		global.Logger.Errorf("Failed to check if work is superseded with error: %s", err.Error())
+	} else if superseded {
+		return "Work superseded by a newer one", true
+	}
+
+	return "", false
+}
+
+func isWorkSuperseded(work *commonTypes.WorkDispatched) (bool, error) {
+	if commonGlobal.Redis == nil {
+		return false, nil
+	}
+
+	return acceptWorkScript.Run(
+		context.Background(),
+		commonGlobal.Redis,
+		[]string{fmt.Sprintf(commonConsts.REDIS_WorkLatestDispatchKeyTemplate, work.AccountID)},
+		work.DispatchAt.UnixMicro(), // Lua numbers are float64
+		commonConsts.REDIS_WorkLatestDispatchExpires.Milliseconds(),
+	).Bool()
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+func TestIsWorkExpired(t *testing.T) {
+	if _, expired := IsWorkExpired(&commonTypes.WorkDispatched{
+		DispatchAt: time.Now().Add(-time.Hour),
+		DropAfter:  time.Now().Add(-time.Minute),
+	}); !expired {
+		t.Error("work past DropAfter should be expired")
+	}
+
+	if reason, expired := IsWorkExpired(&commonTypes.WorkDispatched{
+		DispatchAt: time.Now(),
+		DropAfter:  time.Now().Add(time.Hour),
+	}); expired {
+		t.Errorf("work should not be expired: %s", reason)
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"runtime/debug"
+	"sync"
+)
+
+// Items in progress of works, reported if collectors panic on them
+var processingItems sync.Map // *commonTypes.WorkDispatched : string
+
+// WorkPanic : Panic recovered in work or RPC handlers, so one malformed item never takes down the worker
+type WorkPanic struct {
+	Value  interface{}
+	Stack  string
+	ItemID string // Item in progress when panicked, empty if unknown
+}
+
+func (p *WorkPanic) Error() string {
+	if p.ItemID != "" {
+		return fmt.Sprintf("Panicked on item %s: %v", p.ItemID, p.Value)
+	}
+	return fmt.Sprintf("Panicked: %v", p.Value)
+}
+
+// ProcessingItem : Mark item of work in progress, so it can be reported if collector panics on it
+func ProcessingItem(work *commonTypes.WorkDispatched, itemID string) {
+	processingItems.Store(work, itemID)
+}
+
+// RecoverWork : Call with recover() in deferred function of work, nil if not panicked.
+// Marks of items in progress are cleared either way.
+func RecoverWork(work *commonTypes.WorkDispatched, recovered interface{}) *WorkPanic {
+	itemID, _ := processingItems.LoadAndDelete(work)
+
+	workPanic := RecoverPanic(recovered)
+	if workPanic == nil {
+		return nil
+	}
+	if itemID != nil {
+		workPanic.ItemID = itemID.(string)
+	}
+
+	global.Logger.Errorf("Work of %s (%s) %s\n%s", work.Username, work.Platform, workPanic.Error(), workPanic.Stack)
+
+	return workPanic
+}
+
+// RecoverPanic : Call with recover() in deferred function, nil if not panicked
+func RecoverPanic(recovered interface{}) *WorkPanic {
+	if recovered == nil {
+		return nil
+	}
+
+	return &WorkPanic{
+		Value: recovered,
+		Stack: string(debug.Stack()),
+	}
+}
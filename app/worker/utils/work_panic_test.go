@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/app/worker/global"
+	commonTypes "github.com/Crossbell-Box/OperatorSync/common/types"
+	"go.uber.org/zap"
+	"strings"
+	"testing"
+)
+
+func TestRecoverWork(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	global.Logger = logger.Sugar()
+
+	work := &commonTypes.WorkDispatched{Platform: "test", Username: "panic"}
+
+	collect := func(items []*string) (workPanic *WorkPanic) {
+		defer func() {
+			workPanic = RecoverWork(work, recover())
+		}()
+
+		for i, item := range items {
+			ProcessingItem(work, string(rune('a'+i)))
+			_ = *item // Malformed item without field
+		}
+		return nil
+	}
+
+	value := "ok"
+	if workPanic := collect([]*string{&value}); workPanic != nil {
+		t.Fatalf("Unexpected panic: %v", workPanic)
+	}
+	if _, ok := processingItems.Load(work); ok {
+		t.Error("Item mark not cleared after work finished")
+	}
+
+	workPanic := collect([]*string{&value, nil})
+	if workPanic == nil {
+		t.Fatal("Panic not recovered")
+	}
+	if workPanic.ItemID != "b" || !strings.Contains(workPanic.Error(), "item b") {
+		t.Errorf("Unexpected item of panic: %s", workPanic.Error())
+	}
+	if !strings.Contains(workPanic.Stack, "TestRecoverWork") {
+		t.Errorf("Stack trace of panic site expected, got: %s", workPanic.Stack)
+	}
+	if _, ok := processingItems.Load(work); ok {
+		t.Error("Item mark not cleared after panic")
+	}
+}
@@ -1,8 +1,17 @@
 package config
 
+import "time"
+
 type Config struct {
 	MQConnString    string // RabbitMQ
+	MQPrefetch      int    // Unacknowledged messages per consumer
+	MQMaxRedelivery int    // Before sent to dead letter queue
 	RedisConnString string // Redis
 	WorkerRPCPort   string // Internal RPC port, default 22915
+
+	ShutdownTimeout time.Duration // Wait for works in progress on SIGTERM
+
 	DevelopmentMode bool
+
+	PlatformSettingsFile string // Platform settings overrides (YAML / JSON), optional
 }
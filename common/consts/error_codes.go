@@ -10,10 +10,17 @@ package consts
 const (
 	ERROR_CODE_UNSUPPORTED_PLATFORM           = 10101 // Submit request errors
 	ERROR_CODE_INVALID_FORMAT                 = 10102
+	ERROR_CODE_PLATFORM_DISABLED              = 10103
+	ERROR_CODE_WORK_EXPIRED                   = 10104 // Work past DropAfter or superseded, not an error of account
+	ERROR_CODE_ACCOUNT_NOT_FOUND              = 10105 // Account (or its feed) gone upstream
+	ERROR_CODE_ACCOUNT_PRIVATE                = 10106 // Account not accessible without authorization
 	ERROR_CODE_HTTP_REQUEST_FAILED            = 10201 // Request errors
 	ERROR_CODE_FAILED_TO_PARSE_FEEDS          = 10202
 	ERROR_CODE_FAILED_TO_FIND_NECESSARY_FIELD = 10203
+	ERROR_CODE_NOT_MODIFIED                   = 10204 // Not an error actually, work succeeded with nothing new
 	ERROR_CODE_FAILED_TO_PARSE_JSON           = 10301 // System internal errors
+	ERROR_CODE_WORK_PANICKED                  = 10302 // Collector panicked (like on malformed item), recovered
 	ERROR_CODE_FAILED_TO_UPLOAD               = 10401 // External system errors (like rate limit)
-
+	ERROR_CODE_CIRCUIT_OPEN                   = 10402 // Upstream host kept failing, requests paused for a while
+	ERROR_CODE_RATE_LIMITED                   = 10403 // Too many requests to upstream host, would wait too long
 )
@@ -6,11 +6,18 @@ import "time"
 const (
 	MQSETTINGS_PublishTimeOut = 5 * time.Second
 
-	MQSETTINGS_FeedCollectDispatchQueueName   = "cos_q_feed_dispatch"
-	MQSETTINGS_FeedCollectRetrieveQueueName   = "cos_q_feed_retrieve"
-	MQSETTINGS_FeedCollectIdentifierField     = "cos-status-identifier"
-	MQSETTINGS_FeedCollectSucceededIdentifier = "succeeded"
-	MQSETTINGS_FeedCollectFailedIdentifier    = "failed"
+	MQSETTINGS_FeedCollectDispatchExchangeName       = "cos_x_feed_dispatch"    // Topic exchange, routed by platform
+	MQSETTINGS_FeedCollectDispatchRoutingKeyTemplate = "feed.%s"                // platform
+	MQSETTINGS_FeedCollectDispatchQueueNameTemplate  = "cos_q_feed_dispatch.%s" // platform, consumed by workers serving it
+	MQSETTINGS_FeedCollectRetrieveQueueName          = "cos_q_feed_retrieve"
+	MQSETTINGS_FeedCollectIdentifierField            = "cos-status-identifier"
+	MQSETTINGS_FeedCollectSucceededIdentifier        = "succeeded"
+	MQSETTINGS_FeedCollectFailedIdentifier           = "failed"
+	MQSETTINGS_FeedCollectExpiredIdentifier          = "expired"
+	MQSETTINGS_DeliveryCountField                    = "cos-delivery-count"
+
+	MQSETTINGS_DeadLetterExchangeName = "cos_x_dead_letter"
+	MQSETTINGS_DeadLetterQueueName    = "cos_q_dead_letter"
 
 	MQSETTINGS_ReconnectDelay = 3 * time.Second
 	MQSETTINGS_ReconnectLimit = 3 // Maximum retry before process shutdown
@@ -4,5 +4,24 @@ import "time"
 
 const (
 	REDIS_FeedCollectResultKeyTemplate = "cos:com:%s:%s:%d" // platform : username : timestamp (work dispatched)
-	REDIS_FeedCollectResultExpires     = 1 * time.Hour
+	REDIS_FeedCollectResultPendingKey  = "cos:com:pending"  // Sorted set, result key : stored time, removed once server processed it
+	REDIS_FeedCollectResultReplaysKey  = "cos:com:replays"  // Hash, result key : times replayed
+	REDIS_FeedCollectResultArchivedKey = "cos:com:archived" // Sorted set, result key : archived time, given up after replayed too many times
+	REDIS_FeedCollectResultExpires     = 7 * 24 * time.Hour // Results never processed are dropped, accounts are collected again anyway
+
+	REDIS_WorkLatestDispatchKeyTemplate = "cos:work:latest:%d" // account ID, dispatch time (us) of latest work accepted by workers
+	REDIS_WorkLatestDispatchExpires     = 24 * time.Hour
+
+	REDIS_HttpValidatorsKeyTemplate = "cos:http:%s" // url, ETag / Last-Modified of last succeeded request
+	REDIS_HttpValidatorsExpires     = 7 * 24 * time.Hour
+
+	REDIS_WorkerMetricsKey     = "cos:metrics:workers" // Hash, worker ID : metrics JSON
+	REDIS_WorkerMetricsExpires = 5 * time.Minute       // Consider worker gone if not reported
+
+	REDIS_WorkerHeartbeatKey     = "cos:heartbeat:workers" // Hash, worker ID : heartbeat JSON
+	REDIS_WorkerHeartbeatExpires = 30 * time.Second        // Consider worker gone if no heartbeat, works of its platforms won't be dispatched
+
+	REDIS_PlatformSettingsKey           = "cos:cfg:platforms" // Hash, platform : settings JSON
+	REDIS_PlatformSettingsUpdateChannel = "cos:cfg:platforms:updated"
+	REDIS_PlatformSettingsRefreshPeriod = 1 * time.Minute // In case update notifications are missed
 )
@@ -0,0 +1,7 @@
+package consts
+
+import "time"
+
+const (
+	SHUTDOWN_CLOSE_TIMEOUT = 10 * time.Second // For closing resources after drained
+)
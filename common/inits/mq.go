@@ -1,8 +1,10 @@
 package inits
 
 import (
+	"context"
 	"github.com/Crossbell-Box/OperatorSync/common/consts"
 	"github.com/Crossbell-Box/OperatorSync/common/global"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"go.uber.org/zap"
 	"time"
@@ -41,13 +43,12 @@ func MQ(MQConnString string, logger *zap.SugaredLogger) error {
 			}
 
 			// Waiting for error
-			for {
-				err := <-notifyClose
-				if err != nil {
-					logger.Errorf("MQ connection closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
-					break
-				}
-
+			if err := <-notifyClose; err != nil {
+				logger.Errorf("MQ connection closed with error %d (%s), preparing to reconnect", err.Code, err.Error())
+			} else {
+				// Closed on purpose (shutdown), no need to reconnect
+				logger.Infof("MQ connection closed.")
+				return
 			}
 		}
 	}()
@@ -60,5 +61,12 @@ func MQ(MQConnString string, logger *zap.SugaredLogger) error {
 		}
 	}
 
+	// Closed after works in progress finished, unacknowledged deliveries are requeued
+	shutdown.OnClose(func(_ context.Context) {
+		if conn := global.MQ; conn != nil {
+			_ = conn.Close()
+		}
+	})
+
 	return nil
 }
@@ -0,0 +1,29 @@
+package inits
+
+import (
+	"context"
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"go.uber.org/zap"
+	"time"
+)
+
+// PlatformSettings : Load settings file (if any) and keep runtime settings synced, should be called after redis initialized
+func PlatformSettings(settingsFile string, logger *zap.SugaredLogger) error {
+	if settingsFile != "" {
+		if err := platforms.LoadSettingsFile(settingsFile); err != nil {
+			return err
+		}
+		logger.Infof("Platform settings loaded from %s", settingsFile)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := platforms.FetchRuntimeSettings(ctx, logger); err != nil {
+		return err
+	}
+
+	platforms.WatchRuntimeSettings(logger)
+
+	return nil
+}
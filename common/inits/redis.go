@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/Crossbell-Box/OperatorSync/common/global"
+	"github.com/Crossbell-Box/OperatorSync/common/shutdown"
 	"github.com/redis/go-redis/v9"
 	"time"
 )
@@ -26,5 +27,11 @@ func Redis(conn string) error {
 		return fmt.Errorf("failed to connect to redis: %v", err)
 	}
 
+	// Closed after works in progress finished
+	client := global.Redis
+	shutdown.OnClose(func(_ context.Context) {
+		_ = client.Close()
+	})
+
 	return nil
 }
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// AdminAuth : Bearer token check for admin endpoints of server and worker
+func AdminAuth(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		reqToken := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) != 1 {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"ok":      false,
+				"message": "Unauthorized",
+				"result":  nil,
+			})
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package mq
+
+import (
+	"context"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// deadLetterOrigin : Where message is dead-lettered from, recorded by broker in x-death header
+func deadLetterOrigin(d *amqp.Delivery) (string, string, []string, string) {
+	deaths, ok := d.Headers["x-death"].([]interface{})
+	if !ok || len(deaths) == 0 {
+		return "", "", nil, ""
+	}
+	death, ok := deaths[0].(amqp.Table)
+	if !ok {
+		return "", "", nil, ""
+	}
+
+	queue, _ := death["queue"].(string)
+	exchange, _ := death["exchange"].(string)
+	reason, _ := death["reason"].(string)
+
+	var routingKeys []string
+	if rawKeys, ok := death["routing-keys"].([]interface{}); ok {
+		for _, rawKey := range rawKeys {
+			if key, ok := rawKey.(string); ok {
+				routingKeys = append(routingKeys, key)
+			}
+		}
+	}
+
+	return queue, exchange, routingKeys, reason
+}
+
+func parseDeadLetter(d *amqp.Delivery) types.DeadLetter {
+	queue, _, _, reason := deadLetterOrigin(d)
+
+	headers := make(map[string]interface{})
+	for k, v := range d.Headers {
+		if k == "x-death" {
+			continue
+		}
+		headers[k] = v
+	}
+
+	return types.DeadLetter{
+		Queue:         queue,
+		Reason:        reason,
+		DeliveryCount: DeliveryCount(d),
+		ContentType:   d.ContentType,
+		Headers:       headers,
+		Body:          string(d.Body),
+	}
+}
+
+// InspectDeadLetters : Peek at most limit dead letters without removing them
+func InspectDeadLetters(ch *amqp.Channel, limit int) ([]types.DeadLetter, error) {
+	deadLetters := []types.DeadLetter{}
+
+	var lastTag uint64
+	for len(deadLetters) < limit {
+		d, ok, err := ch.Get(consts.MQSETTINGS_DeadLetterQueueName, false)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			// Empty
+			break
+		}
+		lastTag = d.DeliveryTag
+		deadLetters = append(deadLetters, parseDeadLetter(&d))
+	}
+
+	if lastTag > 0 {
+		// Put all back
+		if err := ch.Nack(lastTag, true, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return deadLetters, nil
+}
+
+// ReplayDeadLetters : Publish at most limit dead letters back to where they came from, with delivery count reset.
+// Works past DropAfter are discarded by workers as expired, so they're safe to replay.
+func ReplayDeadLetters(ch *amqp.Channel, limit int) ([]types.DeadLetter, error) {
+	replayed := []types.DeadLetter{}
+
+	for len(replayed) < limit {
+		d, ok, err := ch.Get(consts.MQSETTINGS_DeadLetterQueueName, false)
+		if err != nil {
+			return replayed, err
+		} else if !ok {
+			// Empty
+			break
+		}
+
+		_, exchange, routingKeys, _ := deadLetterOrigin(&d)
+		if len(routingKeys) == 0 {
+			// Unknown origin, keep it
+			_ = d.Nack(false, true)
+			return replayed, fmt.Errorf("unknown origin of dead letter: %s", string(d.Body))
+		}
+
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			if k == "x-death" || k == "x-first-death-exchange" || k == "x-first-death-queue" || k == "x-first-death-reason" || k == consts.MQSETTINGS_DeliveryCountField {
+				continue
+			}
+			headers[k] = v
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), consts.MQSETTINGS_PublishTimeOut)
+		err = ch.PublishWithContext(
+			ctx,
+			exchange,
+			routingKeys[0],
+			false,
+			false,
+			republishing(&d, headers),
+		)
+		cancel()
+		if err != nil {
+			_ = d.Nack(false, true)
+			return replayed, err
+		}
+
+		if err := d.Ack(false); err != nil {
+			return replayed, err
+		}
+		replayed = append(replayed, parseDeadLetter(&d))
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,40 @@
+package mq
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"testing"
+)
+
+func TestParseDeadLetter(t *testing.T) {
+	d := amqp.Delivery{
+		Headers: amqp.Table{
+			consts.MQSETTINGS_DeliveryCountField: int32(3),
+			"x-death": []interface{}{
+				amqp.Table{
+					"queue":        consts.MQSETTINGS_FeedCollectRetrieveQueueName,
+					"exchange":     "",
+					"reason":       "rejected",
+					"routing-keys": []interface{}{consts.MQSETTINGS_FeedCollectRetrieveQueueName},
+				},
+			},
+		},
+		Body: []byte("{}"),
+	}
+
+	deadLetter := parseDeadLetter(&d)
+	if deadLetter.Queue != consts.MQSETTINGS_FeedCollectRetrieveQueueName || deadLetter.Reason != "rejected" || deadLetter.DeliveryCount != 3 {
+		t.Errorf("unexpected dead letter: %+v", deadLetter)
+	}
+	if _, ok := deadLetter.Headers["x-death"]; ok {
+		t.Error("x-death should be hidden")
+	}
+
+	if _, _, routingKeys, _ := deadLetterOrigin(&d); len(routingKeys) != 1 || routingKeys[0] != consts.MQSETTINGS_FeedCollectRetrieveQueueName {
+		t.Errorf("unexpected routing keys: %v", routingKeys)
+	}
+
+	if DeliveryCount(&amqp.Delivery{}) != 0 {
+		t.Error("should be 0 without header")
+	}
+}
@@ -0,0 +1,28 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrNotConfirmed : Broker refused to take the message
+var ErrNotConfirmed = errors.New("publishing not confirmed by broker")
+
+// PublishConfirmed : Publish message, and wait for broker confirmation if channel is in confirm mode
+func PublishConfirmed(ctx context.Context, ch *amqp.Channel, exchange string, key string, msg amqp.Publishing) error {
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, key, false, false, msg)
+	if err != nil {
+		return err
+	} else if confirmation == nil {
+		// Not in confirm mode
+		return nil
+	}
+
+	if acked, err := confirmation.WaitContext(ctx); err != nil {
+		return err
+	} else if !acked {
+		return ErrNotConfirmed
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package mq
+
+import (
+	"context"
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeliveryCount : Times this message has been delivered before, tracked in headers
+func DeliveryCount(d *amqp.Delivery) int {
+	switch count := d.Headers[consts.MQSETTINGS_DeliveryCountField].(type) {
+	case int32:
+		return int(count)
+	case int64:
+		return int(count)
+	case int:
+		return count
+	default:
+		return 0
+	}
+}
+
+// Retry : Publish message again with delivery count increased, or send to dead letter queue if exceeded.
+// Original message is settled (acked / rejected) in both cases.
+func Retry(ch *amqp.Channel, d *amqp.Delivery, maxRedelivery int) error {
+	count := DeliveryCount(d) + 1
+	if count > maxRedelivery {
+		// Poison message
+		return d.Nack(false, false)
+	}
+
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[consts.MQSETTINGS_DeliveryCountField] = int32(count)
+
+	ctx, cancel := context.WithTimeout(context.Background(), consts.MQSETTINGS_PublishTimeOut)
+	defer cancel()
+
+	if err := PublishConfirmed(
+		ctx,
+		ch,
+		d.Exchange,
+		d.RoutingKey,
+		republishing(d, headers),
+	); err != nil {
+		// Let broker redeliver it
+		_ = d.Nack(false, true)
+		return err
+	}
+
+	return d.Ack(false)
+}
+
+// republishing : Same message with headers replaced
+func republishing(d *amqp.Delivery, headers amqp.Table) amqp.Publishing {
+	return amqp.Publishing{
+		DeliveryMode: amqp.Persistent,
+		ContentType:  d.ContentType,
+		Headers:      headers,
+		Expiration:   d.Expiration,
+		Timestamp:    d.Timestamp,
+		Body:         d.Body,
+	}
+}
+
+// CheckRedelivered : Messages redelivered by broker (consumer crashed or disconnected before settled)
+// are counted and published again, so a message crashing consumers can't loop forever.
+// Returns false if message is taken over and shouldn't be processed now.
+func CheckRedelivered(ch *amqp.Channel, d *amqp.Delivery, maxRedelivery int) (bool, error) {
+	if !d.Redelivered {
+		return true, nil
+	}
+
+	return false, Retry(ch, d, maxRedelivery)
+}
@@ -0,0 +1,109 @@
+package mq
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// FeedCollectDispatchRoutingKey : Routing key of works dispatched for platform
+func FeedCollectDispatchRoutingKey(platform string) string {
+	return fmt.Sprintf(consts.MQSETTINGS_FeedCollectDispatchRoutingKeyTemplate, platform)
+}
+
+// FeedCollectDispatchQueueName : Queue of works dispatched for platform
+func FeedCollectDispatchQueueName(platform string) string {
+	return fmt.Sprintf(consts.MQSETTINGS_FeedCollectDispatchQueueNameTemplate, platform)
+}
+
+// DeclareFeedCollectTopology : Durable dispatch queue of each platform bound to dispatch exchange & retrieve queue,
+// with dead letters of all routed to dead letter queue.
+// Should be the same on server and worker, or declaration would fail.
+func DeclareFeedCollectTopology(ch *amqp.Channel) error {
+	if err := ch.ExchangeDeclare(
+		consts.MQSETTINGS_DeadLetterExchangeName,
+		amqp.ExchangeFanout,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	if _, err := ch.QueueDeclare(
+		consts.MQSETTINGS_DeadLetterQueueName,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	if err := ch.QueueBind(
+		consts.MQSETTINGS_DeadLetterQueueName,
+		"",
+		consts.MQSETTINGS_DeadLetterExchangeName,
+		false,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	deadLetterArgs := amqp.Table{
+		"x-dead-letter-exchange": consts.MQSETTINGS_DeadLetterExchangeName,
+	}
+
+	if err := declareQueue(ch, consts.MQSETTINGS_FeedCollectRetrieveQueueName, deadLetterArgs); err != nil {
+		return err
+	}
+
+	if err := ch.ExchangeDeclare(
+		consts.MQSETTINGS_FeedCollectDispatchExchangeName,
+		amqp.ExchangeTopic,
+		true,
+		false,
+		false,
+		false,
+		nil,
+	); err != nil {
+		return err
+	}
+
+	// Works wait in queue of their platform until a worker serving it shows up.
+	// They never expire in queue, workers discard them after DropAfter instead, so dead letters are failures only.
+	for _, platformID := range platforms.IDs() {
+		queueName := FeedCollectDispatchQueueName(platformID)
+		if err := declareQueue(ch, queueName, deadLetterArgs); err != nil {
+			return err
+		}
+
+		if err := ch.QueueBind(
+			queueName,
+			FeedCollectDispatchRoutingKey(platformID),
+			consts.MQSETTINGS_FeedCollectDispatchExchangeName,
+			false,
+			nil,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func declareQueue(ch *amqp.Channel, queueName string, args amqp.Table) error {
+	_, err := ch.QueueDeclare(
+		queueName,
+		true,
+		false,
+		false,
+		false,
+		args,
+	)
+	return err
+}
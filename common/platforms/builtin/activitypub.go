@@ -0,0 +1,27 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+const (
+	ActivityPubWebFingerLink = "https://{{instance}}/.well-known/webfinger?resource=acct:{{username}}@{{instance}}"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "activitypub",
+		Metadata: types.PlatformMeta{
+			Name:               "ActivityPub",
+			FeedLink:           ActivityPubWebFingerLink, // Resolve actor, then walk its outbox
+			MinRefreshGap:      10 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      false,
+			Limit1Account:      false,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
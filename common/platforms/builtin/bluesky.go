@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "bluesky",
+		Metadata: types.PlatformMeta{
+			Name:               "Bluesky",
+			FeedLink:           "{{bluesky_pds}}/xrpc/app.bsky.feed.getAuthorFeed?actor={{username}}&filter=posts_with_replies&limit=50",
+			MinRefreshGap:      10 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      false,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,4 @@
+// Package builtin registers meta of all built-in platforms.
+// Import it for side effects in any binary that needs the platform registry (collectors are registered by worker);
+// private platforms can be registered the same way with their own blank imports.
+package builtin
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "custom_feed",
+		Metadata: types.PlatformMeta{
+			Name:               "RSS / Atom / JSON Feed",
+			FeedLink:           "{{username}}", // Username is feed URL
+			MinRefreshGap:      30 * time.Minute,
+			MaxRefreshGap:      6 * time.Hour,
+			IsMediaAttachments: false,
+			HTML2Markdown:      true,
+			Limit1Account:      false,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "mastodon",
+		Metadata: types.PlatformMeta{
+			Name:               "Mastodon",
+			FeedLink:           "https://{{instance}}/@{{username}}.rss",
+			MinRefreshGap:      10 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      false,
+			Limit1Account:      false,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "medium",
+		Metadata: types.PlatformMeta{
+			Name:               "Medium",
+			FeedLink:           "https://medium.com/feed/@{{username}}",
+			MinRefreshGap:      20 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: false,
+			HTML2Markdown:      true,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "nostr",
+		Metadata: types.PlatformMeta{
+			Name:               "Nostr",
+			FeedLink:           "", // Collect from relays (NOSTR_RELAYS) directly
+			MinRefreshGap:      10 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: false,
+			HTML2Markdown:      false,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "pinterest",
+		Metadata: types.PlatformMeta{
+			Name:               "Pinterest",
+			FeedLink:           "https://www.pinterest.com/{{username}}/feed.rss",
+			MinRefreshGap:      30 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      false,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
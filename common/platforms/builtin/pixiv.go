@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "pixiv",
+		Metadata: types.PlatformMeta{
+			Name:               "pixiv",
+			FeedLink:           "{{rsshub_stateful}}/pixiv/user/{{username}}", // Actually is UserID
+			MinRefreshGap:      1 * time.Hour,
+			MaxRefreshGap:      12 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      false,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassStateful,
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "podcast",
+		Metadata: types.PlatformMeta{
+			Name:               "Podcast",
+			FeedLink:           "{{username}}", // Username is podcast RSS URL
+			MinRefreshGap:      1 * time.Hour,
+			MaxRefreshGap:      24 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      true,
+			Limit1Account:      false,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "substack",
+		Metadata: types.PlatformMeta{
+			Name:               "Substack",
+			FeedLink:           "https://{{username}}.substack.com/feed",
+			MinRefreshGap:      20 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: false,
+			HTML2Markdown:      true,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
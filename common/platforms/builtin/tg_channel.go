@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "tg_channel",
+		Metadata: types.PlatformMeta{
+			Name:               "Telegram Channel",
+			FeedLink:           "{{rsshub_stateless}}/telegram/channel/{{username}}/includeServiceMsg=0.json",
+			MinRefreshGap:      30 * time.Minute,
+			MaxRefreshGap:      1 * time.Hour,
+			IsMediaAttachments: false,
+			HTML2Markdown:      false,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassStateless,
+	})
+}
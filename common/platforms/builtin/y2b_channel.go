@@ -0,0 +1,23 @@
+package builtin
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/platforms"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"time"
+)
+
+func init() {
+	platforms.Register(&platforms.Definition{
+		PlatformID: "y2b_channel",
+		Metadata: types.PlatformMeta{
+			Name:               "YouTube Channel",
+			FeedLink:           "https://www.youtube.com/feeds/videos.xml?channel_id={{username}}", // Channel ID
+			MinRefreshGap:      1 * time.Hour,
+			MaxRefreshGap:      12 * time.Hour,
+			IsMediaAttachments: true,
+			HTML2Markdown:      false,
+			Limit1Account:      true,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+}
@@ -0,0 +1,36 @@
+package platforms
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+)
+
+// FeedLink replace rule:
+// - Replace {{username}} with real username
+// - Replace {{rsshub_stateful}} with Stateful (Logged in) RSSHub address (healthiest one in pool, when requesting)
+// - Replace {{rsshub_stateless}} with Stateless (Not logged in) RSSHub address (same as above)
+
+// Platform : Meta shared by server and worker, collectors are registered by worker separately
+type Platform interface {
+	ID() string
+	Meta() types.PlatformMeta
+	ConcurrencyClass() types.ConcurrencyClass
+}
+
+// Definition : Platform with plain fields, so most platforms don't need their own type
+type Definition struct {
+	PlatformID  string
+	Metadata    types.PlatformMeta
+	Concurrency types.ConcurrencyClass
+}
+
+func (d *Definition) ID() string {
+	return d.PlatformID
+}
+
+func (d *Definition) Meta() types.PlatformMeta {
+	return d.Metadata
+}
+
+func (d *Definition) ConcurrencyClass() types.ConcurrencyClass {
+	return d.Concurrency
+}
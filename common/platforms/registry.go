@@ -0,0 +1,84 @@
+package platforms
+
+import (
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"sort"
+	"sync"
+)
+
+var (
+	registryLock sync.RWMutex
+	registry     = make(map[string]Platform)
+)
+
+// Register : Add a platform to registry, should be called in platform package's init()
+func Register(p Platform) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	if p == nil {
+		panic("platforms: Register platform is nil")
+	}
+	if _, dup := registry[p.ID()]; dup {
+		panic(fmt.Sprintf("platforms: Register called twice for platform %s", p.ID()))
+	}
+
+	registry[p.ID()] = p
+}
+
+// Unregister : Remove a platform from registry, for tests registering their own platforms to clean up
+func Unregister(id string) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	delete(registry, id)
+}
+
+func Get(id string) (Platform, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	p, ok := registry[id]
+	return p, ok
+}
+
+// Meta : Platform meta with settings applied
+func Meta(id string) (types.PlatformMeta, bool) {
+	p, ok := Get(id)
+	if !ok {
+		return types.PlatformMeta{}, false
+	}
+	return applySettings(id, p.Meta()), true
+}
+
+func IsSupported(id string) bool {
+	_, ok := Get(id)
+	return ok
+}
+
+// IDs : All registered platform IDs, sorted
+func IDs() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// AllMeta : Meta of all enabled platforms, with settings applied
+func AllMeta() map[string]types.PlatformMeta {
+	metas := make(map[string]types.PlatformMeta)
+	for _, id := range IDs() {
+		if meta, ok := Meta(id); ok && IsEnabled(id) {
+			metas[id] = meta
+		}
+	}
+
+	return metas
+}
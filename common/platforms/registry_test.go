@@ -0,0 +1,71 @@
+package platforms
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+// isolate : Platforms registered and settings applied in test are gone after it
+func isolate(t *testing.T) {
+	registryLock.Lock()
+	savedRegistry := registry
+	registry = make(map[string]Platform, len(savedRegistry))
+	for id, p := range savedRegistry {
+		registry[id] = p
+	}
+	registryLock.Unlock()
+
+	settingsLock.Lock()
+	savedFileSettings, savedRuntimeSettings := fileSettings, runtimeSettings
+	settingsLock.Unlock()
+
+	t.Cleanup(func() {
+		registryLock.Lock()
+		registry = savedRegistry
+		registryLock.Unlock()
+
+		settingsLock.Lock()
+		fileSettings, runtimeSettings = savedFileSettings, savedRuntimeSettings
+		settingsLock.Unlock()
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	isolate(t)
+
+	Register(&Definition{
+		PlatformID: "test_platform",
+		Metadata: types.PlatformMeta{
+			Name:          "Test Platform",
+			MinRefreshGap: 10 * time.Minute,
+		},
+		Concurrency: types.ConcurrencyClassDirect,
+	})
+
+	if !IsSupported("test_platform") {
+		t.Fatal("registered platform not found")
+	}
+	if IsSupported("not_registered") {
+		t.Fatal("unregistered platform found")
+	}
+
+	meta, ok := Meta("test_platform")
+	if !ok || meta.Name != "Test Platform" || meta.MinRefreshGap != 10*time.Minute {
+		t.Fatalf("unexpected meta: %v", meta)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("duplicate register should panic")
+			}
+		}()
+		Register(&Definition{PlatformID: "test_platform"})
+	}()
+
+	Unregister("test_platform")
+	if IsSupported("test_platform") {
+		t.Fatal("unregistered platform found")
+	}
+}
@@ -0,0 +1,166 @@
+package platforms
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Settings layers (latter overrides former):
+// - Built-in meta registered by platform packages
+// - Settings file of current deployment (loaded on start)
+// - Runtime settings (changed by admin API, synced with redis)
+
+var (
+	settingsLock    sync.RWMutex
+	fileSettings    = make(map[string]types.PlatformSettings)
+	runtimeSettings = make(map[string]types.PlatformSettings)
+)
+
+// ParseSettings : Parse settings in JSON or YAML format, keyed by platform ID
+func ParseSettings(data []byte, isYAML bool) (map[string]types.PlatformSettings, error) {
+	if isYAML {
+		// Convert to JSON, so durations are parsed the same way
+		var raw map[string]interface{}
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		var err error
+		if data, err = json.Marshal(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	settings := make(map[string]types.PlatformSettings)
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+
+	for id, s := range settings {
+		if err := ValidateSettings(id, &s); err != nil {
+			return nil, err
+		}
+	}
+
+	return settings, nil
+}
+
+// LoadSettingsFile : Load settings file, format decided by extension (.yaml / .yml / .json)
+func LoadSettingsFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read platform settings file: %v", err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	settings, err := ParseSettings(data, ext == ".yaml" || ext == ".yml")
+	if err != nil {
+		return fmt.Errorf("failed to parse platform settings file: %v", err)
+	}
+
+	settingsLock.Lock()
+	fileSettings = settings
+	settingsLock.Unlock()
+
+	return nil
+}
+
+// ValidateSettings : Check if settings are valid for specified platform
+func ValidateSettings(id string, s *types.PlatformSettings) error {
+	if !IsSupported(id) {
+		return fmt.Errorf("platform %s not supported", id)
+	}
+	if s.MinRefreshGap != nil && *s.MinRefreshGap <= 0 {
+		return fmt.Errorf("platform %s: min refresh gap should be positive", id)
+	}
+	if s.MaxRefreshGap != nil && *s.MaxRefreshGap <= 0 {
+		return fmt.Errorf("platform %s: max refresh gap should be positive", id)
+	}
+	if s.MinRefreshGap != nil && s.MaxRefreshGap != nil && *s.MinRefreshGap > *s.MaxRefreshGap {
+		return fmt.Errorf("platform %s: min refresh gap should not be greater than max refresh gap", id)
+	}
+	return nil
+}
+
+// SetRuntimeSettings : Replace all runtime settings
+func SetRuntimeSettings(settings map[string]types.PlatformSettings) {
+	settingsLock.Lock()
+	defer settingsLock.Unlock()
+
+	runtimeSettings = settings
+}
+
+// RuntimeSettings : Current runtime settings
+func RuntimeSettings() map[string]types.PlatformSettings {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+
+	settings := make(map[string]types.PlatformSettings, len(runtimeSettings))
+	for id, s := range runtimeSettings {
+		settings[id] = s
+	}
+
+	return settings
+}
+
+// IsEnabled : Registered and not disabled by settings
+func IsEnabled(id string) bool {
+	if !IsSupported(id) {
+		return false
+	}
+
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+
+	disabled := false
+	for _, layer := range []map[string]types.PlatformSettings{fileSettings, runtimeSettings} {
+		if s, ok := layer[id]; ok && s.Disabled != nil {
+			disabled = *s.Disabled
+		}
+	}
+
+	return !disabled
+}
+
+func applySettings(id string, meta types.PlatformMeta) types.PlatformMeta {
+	settingsLock.RLock()
+	defer settingsLock.RUnlock()
+
+	for _, layer := range []map[string]types.PlatformSettings{fileSettings, runtimeSettings} {
+		s, ok := layer[id]
+		if !ok {
+			continue
+		}
+		if s.FeedLink != nil {
+			meta.FeedLink = *s.FeedLink
+		}
+		if s.MinRefreshGap != nil {
+			meta.MinRefreshGap = time.Duration(*s.MinRefreshGap)
+		}
+		if s.MaxRefreshGap != nil {
+			meta.MaxRefreshGap = time.Duration(*s.MaxRefreshGap)
+		}
+		if s.Limit1Account != nil {
+			meta.Limit1Account = *s.Limit1Account
+		}
+		if s.IsMediaAttachments != nil {
+			meta.IsMediaAttachments = *s.IsMediaAttachments
+		}
+		if s.HTML2Markdown != nil {
+			meta.HTML2Markdown = *s.HTML2Markdown
+		}
+	}
+
+	if meta.MinRefreshGap > meta.MaxRefreshGap {
+		// Layers might conflict with each other
+		meta.MaxRefreshGap = meta.MinRefreshGap
+	}
+
+	return meta
+}
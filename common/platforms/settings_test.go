@@ -0,0 +1,62 @@
+package platforms
+
+import (
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"testing"
+	"time"
+)
+
+func TestSettings(t *testing.T) {
+	isolate(t)
+
+	Register(&Definition{
+		PlatformID: "test_settings",
+		Metadata: types.PlatformMeta{
+			Name:          "Test Settings",
+			FeedLink:      "https://example.com/{{username}}",
+			MinRefreshGap: 10 * time.Minute,
+			MaxRefreshGap: 2 * time.Hour,
+		},
+	})
+
+	fileLayer, err := ParseSettings([]byte(`
+test_settings:
+  min_refresh_gap: 30m
+  feed_link: "https://mirror.example.com/{{username}}"
+`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	settingsLock.Lock()
+	fileSettings = fileLayer
+	settingsLock.Unlock()
+
+	runtimeLayer, err := ParseSettings([]byte(`{"test_settings": {"max_refresh_gap": "6h", "disabled": true}}`), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetRuntimeSettings(runtimeLayer)
+
+	meta, _ := Meta("test_settings")
+	if meta.MinRefreshGap != 30*time.Minute || meta.MaxRefreshGap != 6*time.Hour || meta.FeedLink != "https://mirror.example.com/{{username}}" {
+		t.Fatalf("settings not applied: %v", meta)
+	}
+	if IsEnabled("test_settings") {
+		t.Fatal("platform should be disabled")
+	}
+	if _, ok := AllMeta()["test_settings"]; ok {
+		t.Fatal("disabled platform should not be listed")
+	}
+
+	SetRuntimeSettings(nil)
+	if !IsEnabled("test_settings") {
+		t.Fatal("platform should be enabled after runtime settings reset")
+	}
+
+	if _, err := ParseSettings([]byte(`{"test_settings": {"min_refresh_gap": "2h", "max_refresh_gap": "1h"}}`), false); err == nil {
+		t.Fatal("invalid refresh gaps should be rejected")
+	}
+	if _, err := ParseSettings([]byte(`{"not_registered": {}}`), false); err == nil {
+		t.Fatal("unregistered platform should be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package platforms
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/Crossbell-Box/OperatorSync/common/global"
+	"github.com/Crossbell-Box/OperatorSync/common/types"
+	"go.uber.org/zap"
+	"time"
+)
+
+// FetchRuntimeSettings : Load runtime settings from redis
+func FetchRuntimeSettings(ctx context.Context, logger *zap.SugaredLogger) error {
+	rawSettings, err := global.Redis.HGetAll(ctx, consts.REDIS_PlatformSettingsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]types.PlatformSettings, len(rawSettings))
+	for id, raw := range rawSettings {
+		var s types.PlatformSettings
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			logger.Errorf("Failed to parse runtime settings of platform %s with error: %s", id, err.Error())
+			continue
+		}
+		if err := ValidateSettings(id, &s); err != nil {
+			logger.Warnf("Skip runtime settings of platform %s: %s", id, err.Error())
+			continue
+		}
+		settings[id] = s
+	}
+
+	SetRuntimeSettings(settings)
+
+	return nil
+}
+
+// SaveRuntimeSettings : Save runtime settings of a platform to redis and notify others
+func SaveRuntimeSettings(ctx context.Context, id string, s *types.PlatformSettings) error {
+	rawSettings, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err := global.Redis.HSet(ctx, consts.REDIS_PlatformSettingsKey, id, rawSettings).Err(); err != nil {
+		return err
+	}
+
+	return global.Redis.Publish(ctx, consts.REDIS_PlatformSettingsUpdateChannel, id).Err()
+}
+
+// DeleteRuntimeSettings : Remove runtime settings of a platform from redis and notify others
+func DeleteRuntimeSettings(ctx context.Context, id string) error {
+	if err := global.Redis.HDel(ctx, consts.REDIS_PlatformSettingsKey, id).Err(); err != nil {
+		return err
+	}
+
+	return global.Redis.Publish(ctx, consts.REDIS_PlatformSettingsUpdateChannel, id).Err()
+}
+
+// WatchRuntimeSettings : Reload runtime settings when notified or periodically
+func WatchRuntimeSettings(logger *zap.SugaredLogger) {
+	reload := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := FetchRuntimeSettings(ctx, logger); err != nil {
+			logger.Errorf("Failed to reload platform runtime settings with error: %s", err.Error())
+		}
+	}
+
+	go func() {
+		pubsub := global.Redis.Subscribe(context.Background(), consts.REDIS_PlatformSettingsUpdateChannel)
+		defer pubsub.Close()
+		ch := pubsub.Channel()
+
+		t := time.NewTicker(consts.REDIS_PlatformSettingsRefreshPeriod)
+		defer t.Stop()
+
+		for {
+			select {
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				logger.Infof("Platform %s runtime settings updated, reloading...", msg.Payload)
+				reload()
+			case <-t.C:
+				reload()
+			}
+		}
+	}()
+}
@@ -0,0 +1,91 @@
+package results
+
+import (
+	"context"
+	"errors"
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	"github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// Save : Keep result until server acknowledges it, or expired if never processed
+func Save(ctx context.Context, client *redis.Client, key string, result []byte) error {
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, result, consts.REDIS_FeedCollectResultExpires)
+		pipe.ZAdd(ctx, consts.REDIS_FeedCollectResultPendingKey, redis.Z{
+			Score:  float64(time.Now().Unix()),
+			Member: key,
+		})
+		return nil
+	})
+	return err
+}
+
+// Ack : Result processed, remove it
+func Ack(ctx context.Context, client *redis.Client, key string) error {
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZRem(ctx, consts.REDIS_FeedCollectResultPendingKey, key)
+		pipe.HDel(ctx, consts.REDIS_FeedCollectResultReplaysKey, key)
+		pipe.ZRem(ctx, consts.REDIS_FeedCollectResultArchivedKey, key)
+		return nil
+	})
+	return err
+}
+
+// Touch : Mark result as just reported again, so it won't be considered as stale for a while
+func Touch(ctx context.Context, client *redis.Client, key string) error {
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, consts.REDIS_FeedCollectResultPendingKey, redis.Z{
+			Score:  float64(time.Now().Unix()),
+			Member: key,
+		})
+		pipe.HIncrBy(ctx, consts.REDIS_FeedCollectResultReplaysKey, key, 1)
+		return nil
+	})
+	return err
+}
+
+// Replays : Times result has been reported again
+func Replays(ctx context.Context, client *redis.Client, key string) (int, error) {
+	replays, err := client.HGet(ctx, consts.REDIS_FeedCollectResultReplaysKey, key).Int()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return replays, err
+}
+
+// Archive : Give up on result, it's no longer replayed and expires as usual.
+// Kept in archived index for inspection until then.
+func Archive(ctx context.Context, client *redis.Client, key string) error {
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, consts.REDIS_FeedCollectResultPendingKey, key)
+		pipe.HDel(ctx, consts.REDIS_FeedCollectResultReplaysKey, key)
+		pipe.ZAdd(ctx, consts.REDIS_FeedCollectResultArchivedKey, redis.Z{
+			Score:  float64(time.Now().Unix()),
+			Member: key,
+		})
+		return nil
+	})
+	return err
+}
+
+// PruneArchived : Remove index of archived results already expired
+func PruneArchived(ctx context.Context, client *redis.Client) error {
+	return client.ZRemRangeByScore(
+		ctx,
+		consts.REDIS_FeedCollectResultArchivedKey,
+		"-inf",
+		strconv.FormatInt(time.Now().Add(-consts.REDIS_FeedCollectResultExpires).Unix(), 10),
+	).Err()
+}
+
+// ListStale : Keys of results stored (or touched) before, but still not acknowledged
+func ListStale(ctx context.Context, client *redis.Client, before time.Time, limit int64) ([]string, error) {
+	return client.ZRangeByScore(ctx, consts.REDIS_FeedCollectResultPendingKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(before.Unix(), 10),
+		Count: limit,
+	}).Result()
+}
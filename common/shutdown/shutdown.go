@@ -0,0 +1,150 @@
+package shutdown
+
+import (
+	"context"
+	"github.com/Crossbell-Box/OperatorSync/common/consts"
+	"go.uber.org/zap"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// On SIGTERM (or SIGINT), shutdown goes through:
+//  1. draining:  Draining() reports true, no new works accepted with TryTrack
+//  2. stop:      OnStop hooks stop consuming deliveries and accepting connections
+//  3. wait:      works tracked in flight finish, or timeout
+//  4. close:     OnClose hooks release resources, in reverse order of registration
+
+var (
+	lock       sync.Mutex
+	draining   bool
+	inFlight   int
+	drained    = make(chan struct{})
+	stopping   = make(chan struct{})
+	stopHooks  []func()
+	closeHooks []func(ctx context.Context)
+)
+
+// Draining : Shutdown in progress
+func Draining() bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	return draining
+}
+
+// Stopping : Closed when shutdown starts
+func Stopping() <-chan struct{} {
+	return stopping
+}
+
+// TryTrack : Track a new work in flight, false if draining (work should not start). Call Done when finished.
+func TryTrack() bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if draining {
+		return false
+	}
+	inFlight++
+	return true
+}
+
+// Track : Track a work already accepted, even if draining. Call Done when finished.
+func Track() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	inFlight++
+}
+
+// Done : Tracked work finished
+func Done() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	inFlight--
+	if draining && inFlight == 0 {
+		closeDrained()
+	}
+}
+
+// OnStop : Stop accepting new works when shutdown starts
+func OnStop(hook func()) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	stopHooks = append(stopHooks, hook)
+}
+
+// OnClose : Release resources after works in flight finished
+func OnClose(hook func(ctx context.Context)) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	closeHooks = append(closeHooks, hook)
+}
+
+// Wait : Block until SIGTERM / SIGINT, then shutdown gracefully within timeout
+func Wait(logger *zap.SugaredLogger, timeout time.Duration) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	sig := <-signals
+	signal.Stop(signals)
+
+	logger.Infof("Received %s, start draining...", sig.String())
+
+	Shutdown(logger, timeout)
+}
+
+// Shutdown : Drain and close, returns after closed or timeout
+func Shutdown(logger *zap.SugaredLogger, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	lock.Lock()
+	draining = true
+	close(stopping)
+	if inFlight == 0 {
+		closeDrained()
+	}
+	hooks := stopHooks
+	lock.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+
+	select {
+	case <-drained:
+		logger.Info("All works in progress finished.")
+	case <-ctx.Done():
+		lock.Lock()
+		logger.Warnf("Shutdown timeout with %d works in progress.", inFlight)
+		lock.Unlock()
+	}
+
+	lock.Lock()
+	closes := closeHooks
+	lock.Unlock()
+
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), consts.SHUTDOWN_CLOSE_TIMEOUT)
+	defer closeCancel()
+
+	// Resources opened later might depend on earlier ones
+	for i := len(closes) - 1; i >= 0; i-- {
+		closes[i](closeCtx)
+	}
+
+	logger.Info("Shutdown complete.")
+}
+
+func closeDrained() {
+	select {
+	case <-drained:
+	default:
+		close(drained)
+	}
+}
@@ -0,0 +1,73 @@
+package shutdown
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reset : Back to state before shutdown, with no hooks registered
+func reset() {
+	lock.Lock()
+	defer lock.Unlock()
+
+	draining = false
+	inFlight = 0
+	drained = make(chan struct{})
+	stopping = make(chan struct{})
+	stopHooks = nil
+	closeHooks = nil
+}
+
+func TestShutdown(t *testing.T) {
+	reset()
+	t.Cleanup(reset)
+
+	logger, _ := zap.NewDevelopment()
+
+	var (
+		events     []string
+		eventsLock sync.Mutex
+	)
+	record := func(event string) {
+		eventsLock.Lock()
+		defer eventsLock.Unlock()
+		events = append(events, event)
+	}
+	OnStop(func() {
+		record("stop")
+	})
+	OnClose(func(_ context.Context) {
+		record("close first")
+	})
+	OnClose(func(_ context.Context) {
+		record("close second")
+	})
+
+	if !TryTrack() {
+		t.Fatal("should accept works before shutdown")
+	}
+	go func() {
+		<-Stopping()
+		time.Sleep(50 * time.Millisecond)
+		record("work done")
+		Done()
+	}()
+
+	Shutdown(logger.Sugar(), time.Second)
+
+	if !Draining() || TryTrack() {
+		t.Error("should refuse new works after shutdown")
+	}
+	expected := []string{"stop", "work done", "close second", "close first"}
+	if len(events) != len(expected) {
+		t.Fatalf("unexpected events: %v", events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("unexpected events: %v", events)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package types
+
+type DeadLetter struct {
+	Queue         string                 `json:"queue"`  // Dead-lettered from
+	Reason        string                 `json:"reason"` // rejected / expired / maxlen
+	DeliveryCount int                    `json:"delivery_count"`
+	ContentType   string                 `json:"content_type"`
+	Headers       map[string]interface{} `json:"headers"`
+	Body          string                 `json:"body"`
+}
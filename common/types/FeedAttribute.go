@@ -0,0 +1,38 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// FeedAttribute : Extra typed information of feed, would be note attribute on chain (like duration of podcast episode)
+type FeedAttribute struct {
+	TraitType   string `json:"trait_type"`
+	Value       any    `json:"value"`        // string | number | boolean | null
+	DisplayType string `json:"display_type"` // 'string' | 'number' | 'date' | 'boolean'
+}
+
+type FeedAttributes []FeedAttribute
+
+func (fa *FeedAttributes) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*fa = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(value), fa)
+	case []byte:
+		return json.Unmarshal(value, fa)
+	default:
+		return fmt.Errorf("unsupported type %T for feed attributes", src)
+	}
+}
+
+func (fa FeedAttributes) Value() (driver.Value, error) {
+	if fa == nil {
+		return nil, nil
+	}
+	val, err := json.Marshal(&fa)
+	return string(val), err
+}
@@ -0,0 +1,9 @@
+package types
+
+import "time"
+
+// FeedGap : Time range feeds might be missed in, as the source can't go back far enough
+type FeedGap struct {
+	From time.Time `json:"from"` // DropBefore of the work
+	To   time.Time `json:"to"`   // Oldest feed found
+}
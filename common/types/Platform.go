@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+type PlatformMeta struct {
+	// Meta information
+	Name          string
+	FeedLink      string
+	MinRefreshGap time.Duration
+	MaxRefreshGap time.Duration
+	Limit1Account bool
+
+	// OnChain Settings
+	IsMediaAttachments bool
+	HTML2Markdown      bool
+}
+
+// ConcurrencyClass : Which concurrency bucket a platform's feed collect works share
+type ConcurrencyClass string
+
+const (
+	ConcurrencyClassStateful  ConcurrencyClass = "stateful"  // Logged in RSSHub
+	ConcurrencyClassStateless ConcurrencyClass = "stateless" // Not logged in RSSHub
+	ConcurrencyClassDirect    ConcurrencyClass = "direct"    // Request platform directly
+)
+
+// PlatformSettings : Per deployment overrides of PlatformMeta, nil fields keep the built-in value
+type PlatformSettings struct {
+	Disabled      *bool     `json:"disabled,omitempty"`
+	FeedLink      *string   `json:"feed_link,omitempty"`
+	MinRefreshGap *Duration `json:"min_refresh_gap,omitempty"`
+	MaxRefreshGap *Duration `json:"max_refresh_gap,omitempty"`
+	Limit1Account *bool     `json:"limit_1_account,omitempty"`
+
+	IsMediaAttachments *bool `json:"is_media_attachments,omitempty"`
+	HTML2Markdown      *bool `json:"html2markdown,omitempty"`
+}
@@ -16,11 +16,11 @@ type WorkDispatched struct {
 type WorkSucceeded struct {
 	WorkDispatched
 
-	AcceptedAt  time.Time     `json:"accepted_at"`
-	SucceededAt time.Time     `json:"succeeded_at"`
-	NewInterval time.Duration `json:"new_interval"`
+	AcceptedAt  time.Time `json:"accepted_at"`
+	SucceededAt time.Time `json:"succeeded_at"`
 
 	Feeds []RawFeed `json:"feeds"`
+	Gap   *FeedGap  `json:"gap,omitempty"` // Unresolved after fetching deeper
 }
 
 type WorkFailed struct {
@@ -30,4 +30,6 @@ type WorkFailed struct {
 	ErrorAt     time.Time `json:"error_at"`
 	ErrorCode   uint      `json:"error_code"`
 	ErrorReason string    `json:"error_reason"`
+	ItemID      string    `json:"item_id,omitempty"` // Item in progress when failed, if known
+	Stack       string    `json:"stack,omitempty"`   // Stack trace if panicked
 }
@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+type WorkerLoad struct {
+	InFlight int `json:"in_flight"` // Works in progress
+	Prefetch int `json:"prefetch"`  // Maximum works in progress
+}
+
+type WorkerHeartbeat struct {
+	BeatAt    time.Time  `json:"beat_at"`
+	Platforms []string   `json:"platforms"` // Platforms served, works of other platforms are never routed to this worker
+	Load      WorkerLoad `json:"load"`
+}
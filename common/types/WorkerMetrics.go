@@ -0,0 +1,32 @@
+package types
+
+import "time"
+
+type EndpointStats struct {
+	Endpoint     string    `json:"endpoint"`
+	Healthy      bool      `json:"healthy"`
+	Score        float64   `json:"score"`
+	SuccessRate  float64   `json:"success_rate"`
+	LatencyMs    float64   `json:"latency_ms"`
+	Requests     uint64    `json:"requests"`
+	Failures     uint64    `json:"failures"`
+	LastError    string    `json:"last_error,omitempty"`
+	CooldownTill time.Time `json:"cooldown_till,omitempty"`
+}
+
+type ConcurrencyStats struct {
+	Class      ConcurrencyClass `json:"class"`
+	Limit      int              `json:"limit"`   // Own limit, 0 if limited by class only
+	Weight     int              `json:"weight"`  // Class slots taken by each work
+	Active     int              `json:"active"`  // Works in progress
+	Waiting    int              `json:"waiting"` // Works waiting for slots
+	WaitMs     float64          `json:"wait_ms"` // Moving average of time waited for slots
+	ClassLimit int              `json:"class_limit"`
+	ClassUsed  int              `json:"class_used"` // Slots of class taken by all platforms
+}
+
+type WorkerMetrics struct {
+	ReportedAt  time.Time                   `json:"reported_at"`
+	RSSHub      map[string][]EndpointStats  `json:"rsshub"`      // Class : endpoints
+	Concurrency map[string]ConcurrencyStats `json:"concurrency"` // Platform : stats
+}